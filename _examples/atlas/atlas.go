@@ -11,10 +11,10 @@ var (
 	atlas = firefly.NewAtlas(16, 16)
 
 	knight = atlas.Sprite(0, 0)
-	wall   = atlas.Sprite(2, 0)
-	cat    = atlas.Sprite(3, 0)
-	key    = atlas.Sprite(4, 0)
-	door   = atlas.Sprite(0, 1)
+	wall   = atlas.Sprite(0, 2)
+	cat    = atlas.Sprite(0, 3)
+	key    = atlas.Sprite(0, 4)
+	door   = atlas.Sprite(1, 0)
 )
 
 var frame = 0
@@ -0,0 +1,143 @@
+// Pack PNG images into a single atlas image.
+//
+// Runs on the host at build time. Every PNG is converted into the runtime
+// image format using the nearest colors of the palette, and then all images
+// are packed into one image using [packer.Packer]. The output is the atlas image
+// and the index file listing the region of every image.
+// The image name in the index is the file name without the extension
+// and with whitespace replaced by underscores.
+//
+// Usage:
+//
+//	go run github.com/firefly-zero/firefly-go/cmd/packer -o sprites sprites/*.png
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/packer"
+	"github.com/firefly-zero/firefly-go/firefly/palette"
+)
+
+var errUsage = errors.New("at least one input file expected")
+
+type config struct {
+	output  string
+	padding int
+	width   int
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.output, "o", "atlas", "output image path, the index is saved next to it with .txt extension")
+	flag.IntVar(&cfg.padding, "padding", 0, "empty pixels between images")
+	flag.IntVar(&cfg.width, "width", 0, "the atlas width (default: auto)")
+	flag.Parse()
+	err := run(cfg, flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config, paths []string) error {
+	if len(paths) == 0 {
+		return errUsage
+	}
+	p := packer.New()
+	p.Padding = cfg.padding
+	p.Width = cfg.width
+	for _, path := range paths {
+		img, err := readPNG(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		err = p.Add(imageName(path), img)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	atlas, err := p.Pack()
+	if err != nil {
+		return fmt.Errorf("pack: %w", err)
+	}
+	err = os.WriteFile(cfg.output, atlas.Image.Bytes(), 0o644)
+	if err != nil {
+		return fmt.Errorf("write image: %w", err)
+	}
+	indexPath := strings.TrimSuffix(cfg.output, filepath.Ext(cfg.output)) + ".txt"
+	err = os.WriteFile(indexPath, atlas.Index(), 0o644)
+	if err != nil {
+		return fmt.Errorf("write index: %w", err)
+	}
+	return nil
+}
+
+// Read the PNG file and convert it into the runtime image format.
+func readPNG(path string) (firefly.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return firefly.Image{}, fmt.Errorf("open: %w", err)
+	}
+	defer f.Close()
+	src, err := png.Decode(f)
+	if err != nil {
+		return firefly.Image{}, fmt.Errorf("decode PNG: %w", err)
+	}
+	return convert(src), nil
+}
+
+// Convert the image using the nearest colors of the default palette.
+//
+// Pixels with alpha below 50% are transparent.
+func convert(src image.Image) firefly.Image {
+	bounds := src.Bounds()
+	size := firefly.S(bounds.Dx(), bounds.Dy())
+	colors := make([]firefly.Color, size.W*size.H)
+	used := make(map[firefly.Color]bool)
+	hasTransparency := false
+	for y := range size.H {
+		for x := range size.W {
+			r, g, b, a := src.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			if a < 0x8000 {
+				hasTransparency = true
+				continue
+			}
+			rgb := firefly.NewRGB(uint8(r>>8), uint8(g>>8), uint8(b>>8))
+			c := palette.Sweetie16.Nearest(rgb)
+			colors[y*size.W+x] = c
+			used[c] = true
+		}
+	}
+
+	canvas := firefly.NewCanvas(size)
+	if hasTransparency {
+		// Use a color that the image doesn't have for transparency.
+		for c := firefly.ColorBlack; c <= firefly.ColorDarkGray; c++ {
+			if !used[c] {
+				canvas.Image().SetTransparency(c)
+				canvas.Fill(c)
+				break
+			}
+		}
+	}
+	for i, c := range colors {
+		canvas.SetPixel(firefly.P(i%size.W, i/size.W), c)
+	}
+	return canvas.Image()
+}
+
+// The image name for the index: the file name without the extension
+// and with all whitespace replaced by underscores.
+func imageName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.Join(strings.Fields(name), "_")
+}
@@ -0,0 +1,203 @@
+// Convert SVG images into shapes.
+//
+// Runs on the host at build time. The output is either Go code
+// declaring a slice of [shapes.Shape] or a compact binary file
+// that can be loaded at runtime and deserialized using [encoding.Decode].
+//
+// Usage:
+//
+//	go run github.com/firefly-zero/firefly-go/cmd/svg2shapes [flags] icon.svg
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+	"github.com/firefly-zero/firefly-go/firefly/shapes/encoding"
+	"github.com/firefly-zero/firefly-go/firefly/shapes/svg"
+)
+
+var errUsage = errors.New("exactly one input file expected")
+
+type config struct {
+	input   string
+	output  string
+	binary  bool
+	pkg     string
+	varName string
+	scale   float64
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.output, "o", "", "output file path (default: stdout)")
+	flag.BoolVar(&cfg.binary, "bin", false, "produce binary output instead of Go code")
+	flag.StringVar(&cfg.pkg, "pkg", "main", "package name for the generated Go code")
+	flag.StringVar(&cfg.varName, "var", "", "variable name for the generated Go code (default: from file name)")
+	flag.Float64Var(&cfg.scale, "scale", 1, "multiply all coordinates by this value")
+	flag.Parse()
+	err := run(cfg, flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	cfg.input = args[0]
+	data, err := os.ReadFile(cfg.input)
+	if err != nil {
+		return fmt.Errorf("read input: %w", err)
+	}
+	parsed, err := svg.Parse(data, svg.Options{Scale: float32(cfg.scale)})
+	if err != nil {
+		return fmt.Errorf("parse SVG: %w", err)
+	}
+	var out []byte
+	if cfg.binary {
+		out, err = encoding.Encode(parsed)
+	} else {
+		out, err = generate(cfg, parsed)
+	}
+	if err != nil {
+		return err
+	}
+	if cfg.output == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	err = os.WriteFile(cfg.output, out, 0o644)
+	if err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
+
+// Generate Go code declaring the slice of shapes.
+func generate(cfg config, parsed []shapes.Shape) ([]byte, error) {
+	name := cfg.varName
+	if name == "" {
+		name = varName(cfg.input)
+	}
+	var b bytes.Buffer
+	b.WriteString("// Code generated by svg2shapes. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", cfg.pkg)
+	b.WriteString("import (\n")
+	b.WriteString("\t\"github.com/firefly-zero/firefly-go/firefly\"\n")
+	b.WriteString("\t\"github.com/firefly-zero/firefly-go/firefly/shapes\"\n")
+	b.WriteString(")\n\n")
+	fmt.Fprintf(&b, "// Shapes converted from %s.\n", filepath.Base(cfg.input))
+	fmt.Fprintf(&b, "var %s = []shapes.Shape{\n", name)
+	for _, s := range parsed {
+		lit, err := literal(s)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "\t%s,\n", lit)
+	}
+	b.WriteString("}\n")
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return out, nil
+}
+
+// Make a Go literal for the given shape.
+func literal(s shapes.Shape) (string, error) {
+	switch s := s.(type) {
+	case shapes.Line:
+		return fmt.Sprintf("shapes.Line{A: %s, B: %s, Style: %s}",
+			point(s.A), point(s.B), lineStyle(s.Style)), nil
+	case shapes.Rect:
+		return fmt.Sprintf("shapes.Rect{Point: %s, Size: %s, Style: %s}",
+			point(s.Point), size(s.Size), style(s.Style)), nil
+	case shapes.RoundedRect:
+		return fmt.Sprintf("shapes.RoundedRect{Point: %s, Size: %s, Corner: %s, Style: %s}",
+			point(s.Point), size(s.Size), size(s.Corner), style(s.Style)), nil
+	case shapes.Circle:
+		return fmt.Sprintf("shapes.Circle{Point: %s, Diameter: %d, Style: %s}",
+			point(s.Point), s.Diameter, style(s.Style)), nil
+	case shapes.Ellipse:
+		return fmt.Sprintf("shapes.Ellipse{Point: %s, Size: %s, Style: %s}",
+			point(s.Point), size(s.Size), style(s.Style)), nil
+	case shapes.Polyline:
+		return fmt.Sprintf("shapes.Polyline{Points: %s, Style: %s}",
+			points(s.Points), lineStyle(s.Style)), nil
+	case shapes.Polygon:
+		return fmt.Sprintf("shapes.Polygon{Points: %s, Style: %s}",
+			points(s.Points), style(s.Style)), nil
+	default:
+		return "", fmt.Errorf("%w: %T", encoding.ErrUnsupportedShape, s)
+	}
+}
+
+func point(p firefly.Point) string {
+	return fmt.Sprintf("firefly.P(%d, %d)", p.X, p.Y)
+}
+
+func size(s firefly.Size) string {
+	return fmt.Sprintf("firefly.S(%d, %d)", s.W, s.H)
+}
+
+func points(ps []firefly.Point) string {
+	items := make([]string, len(ps))
+	for i, p := range ps {
+		items[i] = point(p)
+	}
+	return "[]firefly.Point{" + strings.Join(items, ", ") + "}"
+}
+
+func color(c firefly.Color) string {
+	return "firefly.Color" + c.String()
+}
+
+func style(s firefly.Style) string {
+	return fmt.Sprintf(
+		"firefly.Style{FillColor: %s, StrokeColor: %s, StrokeWidth: %d}",
+		color(s.FillColor), color(s.StrokeColor), s.StrokeWidth,
+	)
+}
+
+func lineStyle(s firefly.LineStyle) string {
+	return fmt.Sprintf("firefly.L(%s, %d)", color(s.Color), s.Width)
+}
+
+// Make an exported Go identifier from the file name.
+func varName(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	var b strings.Builder
+	upper := true
+	for _, r := range base {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !isDigit {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && isDigit {
+			b.WriteString("Shapes")
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "Shapes"
+	}
+	return b.String()
+}
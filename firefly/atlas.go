@@ -0,0 +1,170 @@
+package firefly
+
+// Helper for working with spritesheets.
+//
+// By default, the spritesheet is a uniform grid of equal-size sprites
+// without any gaps. Margins, spacing, and named sprites with pivots
+// can be loaded from a metadata file using [packer.LoadMeta].
+//
+// Constructed by [NewAtlas].
+//
+// [packer.LoadMeta]: https://pkg.go.dev/github.com/firefly-zero/firefly-go/firefly/packer#LoadMeta
+type Atlas struct {
+	img        Image
+	spriteSize Size
+	margin     int
+	spacing    int
+	named      map[string]Sprite
+}
+
+// Create a new [Atlas] with the given sprite dimensions.
+func NewAtlas(spriteW, spriteH int) Atlas {
+	return Atlas{spriteSize: S(spriteW, spriteH)}
+}
+
+// Set the underlying spritesheet image for the atlas.
+//
+// Should be called before any [Sprite.Draw].
+// The best is to call it once from [Boot].
+func (a *Atlas) Load(path string) {
+	a.img = LoadImage(path, nil)
+}
+
+// Set the size of the grid cells, overriding the size passed into [NewAtlas].
+func (a *Atlas) SetSpriteSize(s Size) {
+	a.spriteSize = s
+}
+
+// Set the empty space (in pixels) between the image edges and the grid.
+func (a *Atlas) SetMargin(margin int) {
+	a.margin = margin
+}
+
+// Set the empty space (in pixels) between neighboring grid cells.
+func (a *Atlas) SetSpacing(spacing int) {
+	a.spacing = spacing
+}
+
+// Create a reference to a sprite within the atlas.
+//
+// The row is the position of the sprite on the vertical axis,
+// the column is the position on the horizontal axis.
+func (a *Atlas) Sprite(row, col int) Sprite {
+	return Sprite{atlas: a, pos: P(col, row), grid: true}
+}
+
+// Create a reference to a region of any size within the atlas image.
+//
+// Unlike [Atlas.Sprite], the position is in pixels and ignores the grid.
+func (a *Atlas) Region(p Point, s Size) Sprite {
+	return Sprite{atlas: a, pos: p, size: s}
+}
+
+// Give the sprite a name so that it can be found using [Atlas.Named].
+func (a *Atlas) SetNamed(name string, s Sprite) {
+	if a.named == nil {
+		a.named = make(map[string]Sprite)
+	}
+	a.named[name] = s
+}
+
+// Get the sprite with the given name set by [Atlas.SetNamed].
+//
+// If there is no such sprite, the returned sprite doesn't [Sprite.Exists]
+// and drawing it does nothing.
+func (a *Atlas) Named(name string) Sprite {
+	return a.named[name]
+}
+
+// A region of the [Atlas] image.
+//
+// Constructed by [Atlas.Sprite], [Atlas.Region], and [Atlas.Named].
+type Sprite struct {
+	atlas *Atlas
+	// For grid sprites, the column and the row. Otherwise, the position in pixels.
+	pos   Point
+	size  Size
+	pivot Point
+	grid  bool
+}
+
+// Check if the sprite refers to a region in an atlas.
+//
+// It is false for sprites returned by [Atlas.Named] for unknown names.
+func (s Sprite) Exists() bool {
+	return s.atlas != nil
+}
+
+// The size of the sprite in pixels.
+func (s Sprite) Size() Size {
+	if s.atlas == nil {
+		return Size{}
+	}
+	if s.grid {
+		return s.atlas.spriteSize
+	}
+	return s.size
+}
+
+// The position of the sprite in the atlas image.
+func (s Sprite) Point() Point {
+	if s.atlas == nil {
+		return Point{}
+	}
+	if !s.grid {
+		return s.pos
+	}
+	a := s.atlas
+	return P(
+		a.margin+s.pos.X*(a.spriteSize.W+a.spacing),
+		a.margin+s.pos.Y*(a.spriteSize.H+a.spacing),
+	)
+}
+
+// The pivot (origin) point of the sprite relative to its top-left corner.
+//
+// Used by [Sprite.DrawPivot]. Zero unless set by [Sprite.WithPivot].
+func (s Sprite) Pivot() Point {
+	return s.pivot
+}
+
+// A copy of the sprite with the given pivot point.
+func (s Sprite) WithPivot(p Point) Sprite {
+	s.pivot = p
+	return s
+}
+
+// The region of the atlas image occupied by the sprite.
+func (s Sprite) SubImage() SubImage {
+	if s.atlas == nil {
+		return SubImage{}
+	}
+	return s.atlas.img.Sub(s.Point(), s.Size())
+}
+
+// Render the sprite at the given position.
+//
+// The position is the top-left corner of the sprite.
+// Make sure to call [Atlas.Load] first.
+func (s Sprite) Draw(p Point) {
+	if s.atlas == nil {
+		return
+	}
+	s.SubImage().Draw(p)
+}
+
+// Render the sprite so that its pivot is at the given position.
+//
+// For example, if the pivot is at the feet of a character,
+// the point is where the character stands.
+func (s Sprite) DrawPivot(p Point) {
+	s.Draw(p.Sub(s.pivot))
+}
+
+// Render the sprite in the cell of the screen grid
+// where the cell size is the sprite size.
+func (s Sprite) DrawOnGrid(x, y int) {
+	size := s.Size()
+	point := P(x*size.W, y*size.H)
+	s.Draw(point)
+}
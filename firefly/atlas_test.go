@@ -0,0 +1,23 @@
+package firefly_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+func TestAtlas_Sprite(t *testing.T) {
+	t.Parallel()
+	atlas := firefly.NewAtlas(16, 8)
+	sprite := atlas.Sprite(1, 2)
+	want := firefly.P(32, 8)
+	if sprite.Point() != want {
+		t.Errorf("want %v, but got %v", want, sprite.Point())
+	}
+	atlas.SetMargin(1)
+	atlas.SetSpacing(2)
+	want = firefly.P(1+2*18, 1+10)
+	if sprite.Point() != want {
+		t.Errorf("with margin and spacing: want %v, but got %v", want, sprite.Point())
+	}
+}
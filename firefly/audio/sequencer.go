@@ -0,0 +1,423 @@
+package audio
+
+// How much audio time passes between two updates.
+//
+// The runtime calls the update callback 60 times per second.
+const frameTime Time = SampleRate / 60
+
+// The default value of [Sequencer.Lookahead].
+const DefaultLookahead Time = SampleRate / 10
+
+// How long notes are added into the same group of nodes before a new group is started.
+//
+// When all notes in a group finish, the group is cleared and reused.
+const bucketTime Time = SampleRate
+
+// The speed of a [Song].
+type Tempo struct {
+	// Beats per minute.
+	BPM float32
+
+	// How many steps (rows of a [Pattern]) are in one beat.
+	//
+	// For example, 4 steps per beat in 4/4 means that each step is a 16th note.
+	StepsPerBeat int
+}
+
+// The duration of a single step.
+func (t Tempo) Step() Time {
+	return t.Steps(1)
+}
+
+// The duration of the given number of steps.
+//
+// Always use it instead of multiplying [Tempo.Step],
+// so that rounding errors don't accumulate over long songs.
+func (t Tempo) Steps(n int) Time {
+	if t.BPM <= 0 {
+		return 0
+	}
+	spb := float32(max(t.StepsPerBeat, 1))
+	return Time(float32(n) * 60 * SampleRate / (t.BPM * spb))
+}
+
+// The waveform of an [Instrument].
+type Wave uint8
+
+const (
+	WaveSine Wave = iota
+	WaveSquare
+	WaveSawtooth
+	WaveTriangle
+	WaveNoise
+)
+
+// The sound of notes: the waveform and the volume envelope.
+//
+// The envelope durations are relative to the note start and end,
+// and they are converted into [ADSRModulator] for every note.
+type Instrument struct {
+	Wave Wave
+
+	// How long it takes for the note to reach the full volume.
+	Attack Time
+
+	// How long it takes after the attack to go down to the sustain level.
+	Decay Time
+
+	// The volume from 0 to 1 held after the decay until the note ends.
+	Sustain float32
+
+	// How long it takes after the note end for the volume to drop to 0.
+	Release Time
+}
+
+// Add nodes playing the note.
+//
+// The note starts after the given delay. Should be used with fresh nodes
+// because modulators count the time from when the node is added.
+func (i Instrument) Play(parent Node, delay Time, length Time, freq Freq, velocity float32) {
+	// The node is paused until the note starts, and so are its children,
+	// which means the envelope starts with the note.
+	pause := parent.AddPause()
+	pause.Modulate(0, 1, HoldModulator{Time: delay})
+	gain := pause.AddGain(0)
+	attack := min(i.Attack, length)
+	decay := min(attack+i.Decay, length)
+	gain.Modulate(0, velocity, ADSRModulator{
+		Attack:       attack,
+		Decay:        decay,
+		Sustain:      length,
+		SustainLevel: i.Sustain,
+		Release:      length + i.Release,
+	})
+	switch i.Wave {
+	case WaveSine:
+		gain.AddSine(freq, 0)
+	case WaveSquare:
+		gain.AddSquare(freq, 0)
+	case WaveSawtooth:
+		gain.AddSawtooth(freq, 0)
+	case WaveTriangle:
+		gain.AddTriangle(freq, 0)
+	case WaveNoise:
+		gain.AddNoise(int(freq))
+	}
+}
+
+// A single note of a [Pattern].
+type Note struct {
+	// The pitch of the note. Zero means a rest.
+	Freq Freq
+
+	// The duration in steps. At least 1.
+	Steps int
+
+	// The volume from 0 to 1.
+	Velocity float32
+
+	// The index of the instrument in [Song].Instruments.
+	Instrument int
+}
+
+// A note played with the full volume by the first instrument.
+func N(freq Freq, steps int) Note {
+	return Note{Freq: freq, Steps: steps, Velocity: 1}
+}
+
+// Silence for the given number of steps.
+func Rest(steps int) Note {
+	return Note{Steps: steps}
+}
+
+// A sequence of notes played one after another.
+type Pattern []Note
+
+// The duration of the pattern in steps.
+func (p Pattern) Steps() int {
+	steps := 0
+	for _, n := range p {
+		steps += max(n.Steps, 1)
+	}
+	return steps
+}
+
+// Patterns played one after another.
+//
+// The same pattern can be repeated, for example, for a chorus.
+type Channel []Pattern
+
+// The duration of the channel in steps.
+func (c Channel) Steps() int {
+	steps := 0
+	for _, p := range c {
+		steps += p.Steps()
+	}
+	return steps
+}
+
+// A melody: channels played at the same time.
+type Song struct {
+	Tempo       Tempo
+	Instruments []Instrument
+	Channels    []Channel
+
+	// If true, the song starts again when it ends.
+	Loop bool
+}
+
+// The duration of the song in steps, which is the duration of the longest channel.
+func (s Song) Steps() int {
+	steps := 0
+	for _, c := range s.Channels {
+		steps = max(steps, c.Steps())
+	}
+	return steps
+}
+
+// The duration of the song.
+func (s Song) Length() Time {
+	return s.Tempo.Steps(s.Steps())
+}
+
+// A note scheduled at a specific time.
+type Event struct {
+	// The index of the channel in [Song].Channels.
+	Channel int
+
+	// When the note starts, counting from the song start.
+	//
+	// For looped songs, it includes all the previous repetitions.
+	Start Time
+
+	// The duration of the note, not including the release.
+	Length Time
+
+	Note Note
+}
+
+// Notes that start between from (included) and to (excluded).
+//
+// For looped songs, the notes of all repetitions are included.
+// Rests are not included.
+func (s Song) Events(from, to Time) []Event {
+	songSteps := s.Steps()
+	length := s.Length()
+	if songSteps == 0 || length == 0 {
+		return nil
+	}
+	var events []Event
+	// Start one repetition earlier in case of rounding errors.
+	pass := 0
+	if s.Loop {
+		pass = max(int(from/length)-1, 0)
+	}
+	for ; s.Tempo.Steps(pass*songSteps) < to; pass++ {
+		if !s.Loop && pass > 0 {
+			break
+		}
+		for i, c := range s.Channels {
+			events = s.channelEvents(events, i, c, pass*songSteps, from, to)
+		}
+	}
+	return events
+}
+
+// Append events of the channel starting at the given step.
+func (s Song) channelEvents(events []Event, i int, c Channel, step int, from, to Time) []Event {
+	for _, p := range c {
+		for _, n := range p {
+			steps := max(n.Steps, 1)
+			start := s.Tempo.Steps(step)
+			if n.Freq != 0 && start >= from && start < to {
+				events = append(events, Event{
+					Channel: i,
+					Start:   start,
+					Length:  s.Tempo.Steps(step+steps) - start,
+					Note:    n,
+				})
+			}
+			step += steps
+		}
+	}
+	return events
+}
+
+// Plays a [Song] by adding nodes into the audio graph.
+//
+// Notes are added shortly before they should be played (see [Sequencer.Lookahead]),
+// so looped songs can play forever and changes to the song are picked up on the fly.
+//
+// Constructed by [NewSequencer].
+type Sequencer struct {
+	Song Song
+
+	// How far ahead of the current time notes are added into the audio graph.
+	//
+	// Must be longer than a single update.
+	Lookahead Time
+
+	out       Mix
+	channels  []*channel
+	now       Time
+	scheduled Time
+	playing   bool
+}
+
+// The nodes of a single channel.
+type channel struct {
+	mix Mix
+	// Groups of nodes that still have playing notes, the last one is the current.
+	buckets []bucket
+	// Cleared groups that can be reused.
+	free []Mix
+}
+
+type bucket struct {
+	mix Mix
+	// When the bucket was started.
+	start Time
+	// When the last note in the bucket ends.
+	end Time
+}
+
+// Create a sequencer adding nodes under the given parent.
+//
+// Every channel of the song gets its own [Mix], see [Sequencer.Channel].
+func NewSequencer(parent Node, song Song) *Sequencer {
+	s := &Sequencer{
+		Song:      song,
+		Lookahead: DefaultLookahead,
+		out:       parent.AddMix(),
+	}
+	for range song.Channels {
+		s.channels = append(s.channels, &channel{mix: s.out.AddMix()})
+	}
+	return s
+}
+
+// The node all notes of the channel are added under.
+//
+// If the song has fewer channels, a new one is added.
+func (s *Sequencer) Channel(i int) Mix {
+	return s.channel(i).mix
+}
+
+func (s *Sequencer) channel(i int) *channel {
+	for len(s.channels) <= i {
+		s.channels = append(s.channels, &channel{mix: s.out.AddMix()})
+	}
+	return s.channels[i]
+}
+
+// The current playback time, counting from the song start.
+func (s *Sequencer) Time() Time {
+	return s.now
+}
+
+// Check if the song is playing.
+func (s *Sequencer) Playing() bool {
+	return s.playing
+}
+
+// Start playing the song from the current time.
+func (s *Sequencer) Play() {
+	s.playing = true
+	s.schedule()
+}
+
+// Stop playing and silence all notes.
+//
+// The next [Sequencer.Play] starts the song from the beginning.
+func (s *Sequencer) Stop() {
+	s.playing = false
+	s.now = 0
+	s.scheduled = 0
+	for _, c := range s.channels {
+		c.mix.Clear()
+		*c = channel{mix: c.mix}
+	}
+}
+
+// Advance the time by one update and add nodes for the upcoming notes.
+//
+// Must be called on every update while the song is playing.
+// A song without a loop stops when it ends.
+func (s *Sequencer) Update() {
+	if !s.playing {
+		return
+	}
+	s.now += frameTime
+	s.schedule()
+	for _, c := range s.channels {
+		c.cleanup(s.now)
+	}
+	if !s.Song.Loop && s.now >= s.Song.Length() && s.silent() {
+		s.playing = false
+	}
+}
+
+// Add nodes for notes that start before the lookahead ends.
+func (s *Sequencer) schedule() {
+	to := s.now + max(s.Lookahead, frameTime)
+	if to <= s.scheduled {
+		return
+	}
+	for _, e := range s.Song.Events(s.scheduled, to) {
+		n := e.Note
+		if n.Instrument < 0 || n.Instrument >= len(s.Song.Instruments) {
+			continue
+		}
+		inst := s.Song.Instruments[n.Instrument]
+		delay := Time(0)
+		if e.Start > s.now {
+			delay = e.Start - s.now
+		}
+		b := s.channel(e.Channel).current(s.now)
+		inst.Play(b.mix.Node, delay, e.Length, n.Freq, n.Velocity)
+		b.end = max(b.end, e.Start+e.Length+inst.Release)
+	}
+	s.scheduled = to
+}
+
+// Check if all notes have finished.
+func (s *Sequencer) silent() bool {
+	for _, c := range s.channels {
+		if len(c.buckets) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// The bucket to add new notes into.
+func (c *channel) current(now Time) *bucket {
+	if n := len(c.buckets); n > 0 && now-c.buckets[n-1].start < bucketTime {
+		return &c.buckets[n-1]
+	}
+	var mix Mix
+	if n := len(c.free); n > 0 {
+		mix = c.free[n-1]
+		c.free = c.free[:n-1]
+	} else {
+		mix = c.mix.AddMix()
+	}
+	c.buckets = append(c.buckets, bucket{mix: mix, start: now, end: now})
+	return &c.buckets[len(c.buckets)-1]
+}
+
+// Clear the buckets in which all notes have finished.
+func (c *channel) cleanup(now Time) {
+	kept := c.buckets[:0]
+	for i, b := range c.buckets {
+		finished := b.end <= now
+		current := i == len(c.buckets)-1 && now-b.start < bucketTime
+		if finished && !current {
+			b.mix.Clear()
+			c.free = append(c.free, b.mix)
+			continue
+		}
+		kept = append(kept, b)
+	}
+	c.buckets = kept
+}
@@ -0,0 +1,57 @@
+package audio_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly/audio"
+)
+
+func TestTempo(t *testing.T) {
+	t.Parallel()
+	tempo := audio.Tempo{BPM: 120, StepsPerBeat: 4}
+	if got := tempo.Step(); got != 5512 {
+		t.Errorf("want 5512 samples per step, got %d", got)
+	}
+	// Durations are calculated without accumulating rounding errors.
+	if got := tempo.Steps(8); got != audio.Seconds(1) {
+		t.Errorf("want 1 second, got %d samples", got)
+	}
+}
+
+func TestSong_Events(t *testing.T) {
+	t.Parallel()
+	song := audio.Song{
+		Tempo:       audio.Tempo{BPM: 120, StepsPerBeat: 4},
+		Instruments: []audio.Instrument{{Wave: audio.WaveSquare}},
+		Channels: []audio.Channel{
+			{{audio.N(audio.A4, 2), audio.Rest(1), audio.N(audio.C5, 1)}},
+			{{audio.N(audio.E4, 8)}},
+		},
+	}
+	if song.Steps() != 8 || song.Length() != audio.Seconds(1) {
+		t.Fatalf("wrong song length: %d steps", song.Steps())
+	}
+	want := []audio.Event{
+		{Channel: 0, Start: 0, Length: 11025, Note: audio.N(audio.A4, 2)},
+		{Channel: 0, Start: 16537, Length: 5513, Note: audio.N(audio.C5, 1)},
+		{Channel: 1, Start: 0, Length: 44100, Note: audio.N(audio.E4, 8)},
+	}
+	got := song.Events(0, song.Length())
+	if len(got) != len(want) {
+		t.Fatalf("want %d events, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: want %+v, got %+v", i, want[i], got[i])
+		}
+	}
+	if got := song.Events(audio.Seconds(1), audio.Seconds(2)); len(got) != 0 {
+		t.Errorf("a song without a loop must end, got %+v", got)
+	}
+
+	song.Loop = true
+	got = song.Events(audio.Seconds(1), audio.MS(1300))
+	if len(got) != 2 || got[0].Start != audio.Seconds(1) || got[1].Channel != 1 {
+		t.Errorf("the second repetition must start after the first one, got %+v", got)
+	}
+}
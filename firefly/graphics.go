@@ -317,6 +317,37 @@ func NewRGB(r, g, b uint8) RGB {
 	return RGB{R: r, G: g, B: b}
 }
 
+// The default palette (SWEETIE-16).
+var defaultPalette = [16]RGB{
+	{R: 0x1a, G: 0x1c, B: 0x2c},
+	{R: 0x5d, G: 0x27, B: 0x5d},
+	{R: 0xb1, G: 0x3e, B: 0x53},
+	{R: 0xef, G: 0x7d, B: 0x57},
+	{R: 0xff, G: 0xcd, B: 0x75},
+	{R: 0xa7, G: 0xf0, B: 0x70},
+	{R: 0x38, G: 0xb7, B: 0x64},
+	{R: 0x25, G: 0x71, B: 0x79},
+	{R: 0x29, G: 0x36, B: 0x6f},
+	{R: 0x3b, G: 0x5d, B: 0xc9},
+	{R: 0x41, G: 0xa6, B: 0xf6},
+	{R: 0x73, G: 0xef, B: 0xf7},
+	{R: 0xf4, G: 0xf4, B: 0xf4},
+	{R: 0x94, G: 0xb0, B: 0xc2},
+	{R: 0x56, G: 0x6c, B: 0x86},
+	{R: 0x33, G: 0x3c, B: 0x57},
+}
+
+// Get the RGB value of the color in the default palette (SWEETIE-16).
+//
+// The value doesn't change when the palette is modified using [SetColor] or [SetPalette].
+// For [ColorNone] and invalid colors, returns zero (black) RGB.
+func (color Color) DefaultRGB() RGB {
+	if color == ColorNone || color > ColorDarkGray {
+		return RGB{}
+	}
+	return defaultPalette[color-1]
+}
+
 // Style of a shape.
 type Style struct {
 	// The color to use to fill the shape.
@@ -410,6 +441,11 @@ func (f Font) CharHeight() int {
 	return int(f.raw[3])
 }
 
+// Check if both values refer to the same loaded font.
+func (f Font) Eq(other Font) bool {
+	return sameBytes(f.raw, other.raw)
+}
+
 // A loaded image file.
 //
 // Can be loaded using [LoadFile].
@@ -500,6 +536,23 @@ func (i Image) GetPixel(point Point) Color {
 	return Color(color + 1)
 }
 
+// The raw image content in the runtime format.
+//
+// Can be saved into a file using [DumpFile] and then loaded back using [LoadImage].
+// The format of assets can be changed between releases,
+// so don't modify or construct the content manually.
+func (i Image) Bytes() []byte {
+	return i.raw
+}
+
+// Check if both values refer to the same loaded image.
+//
+// The image content is not compared. So, if the image is a [Canvas],
+// it is still considered the same after something is drawn on it.
+func (i Image) Eq(other Image) bool {
+	return sameBytes(i.raw, other.raw)
+}
+
 // A subregion of an image. Constructed using [Image.Sub].
 type SubImage struct {
 	raw   []byte
@@ -538,6 +591,22 @@ func (i SubImage) Height() int {
 	return i.size.H
 }
 
+// Check if both values refer to the same region of the same image.
+func (i SubImage) Eq(other SubImage) bool {
+	return sameBytes(i.raw, other.raw) && i.point == other.point && i.size == other.size
+}
+
+// Get color of a pixel in the subimage.
+//
+// The point is relative to the top-left corner of the subimage.
+// Returns [ColorNone] if out of bounds.
+func (i SubImage) GetPixel(point Point) Color {
+	if point.X < 0 || point.Y < 0 || point.X >= i.size.W || point.Y >= i.size.H {
+		return ColorNone
+	}
+	return i.Image().GetPixel(i.point.Add(point))
+}
+
 // Canvas is an [Image] that can be drawn upon.
 //
 // Constructed by [NewCanvas].
@@ -545,9 +614,19 @@ type Canvas struct {
 	raw []byte
 }
 
+// Create a new canvas of the given size.
+//
+// The image format stores 2 pixels in a byte and the height is derived
+// from the width and the number of pixels. So, a canvas 1 pixel wide
+// with an odd height gets one more row.
 func NewCanvas(s Size) Canvas {
 	const headerSize = 4
-	bodySize := s.W * s.H / 2
+	if s.W == 1 && s.H%2 == 1 {
+		s.H++
+	}
+	// Round up so that the last pixel of odd-sized canvases isn't cut.
+	// For canvases at least 2 pixels wide, the padding is less than a row.
+	bodySize := (s.W*s.H + 1) / 2
 	raw := make([]byte, headerSize+bodySize)
 	raw[0] = 0x22           // magic number
 	raw[1] = byte(s.W)      // width
@@ -566,50 +645,57 @@ func (c Canvas) Image() Image {
 	return Image(c)
 }
 
-// Helper for working with spritesheets.
-//
-// Constructed by [NewAtlas].
-type Atlas struct {
-	img        Image
-	spriteSize Size
+// The canvas size in pixels.
+func (c Canvas) Size() Size {
+	return Image(c).Size()
 }
 
-// Create a new [Atlas] with the given sprite dimensions.
-func NewAtlas(spriteW, spriteH int) Atlas {
-	return Atlas{spriteSize: S(spriteW, spriteH)}
-}
-
-// Set the underlying spritesheet image for the atlas.
+// Set the color of a pixel on the canvas.
 //
-// Should be called before any [Sprite.Draw].
-// The best is to call it once from [Boot].
-func (a *Atlas) Load(path string) {
-	a.img = LoadImage(path, nil)
-}
-
-// Create a reference to a sprite within the atlas.
-func (a *Atlas) Sprite(row, col int) Sprite {
-	pos := P(row*a.spriteSize.W, col*a.spriteSize.H)
-	return Sprite{atlas: a, pos: pos}
-}
-
-type Sprite struct {
-	atlas *Atlas
-	pos   Point
+// Unlike drawing functions, it modifies the canvas memory directly
+// without calling the host, so it's fast enough to be called for every pixel.
+// Out of bounds points and [ColorNone] are ignored.
+func (c Canvas) SetPixel(point Point, color Color) {
+	if color == ColorNone || point.X < 0 || point.Y < 0 {
+		return
+	}
+	width := Image(c).Width()
+	if point.X >= width || point.Y >= Image(c).Height() {
+		return
+	}
+	pixelIndex := point.X + point.Y*width
+	bodyIndex := 4 + pixelIndex/2
+	if bodyIndex >= len(c.raw) {
+		return
+	}
+	value := byte(color-1) & 0b1111
+	if pixelIndex%2 == 0 {
+		c.raw[bodyIndex] = c.raw[bodyIndex]&0b1111 | value<<4
+	} else {
+		c.raw[bodyIndex] = c.raw[bodyIndex]&0b11110000 | value
+	}
 }
 
-// Render the sprite at the given position.
+// Get color of a pixel on the canvas.
 //
-// Make sure to call [Atlas.Load] first.
-func (s Sprite) Draw(p Point) {
-	sub := s.atlas.img.Sub(s.pos, s.atlas.spriteSize)
-	sub.Draw(p)
+// Returns [ColorNone] if out of bounds.
+func (c Canvas) GetPixel(point Point) Color {
+	return Image(c).GetPixel(point)
 }
 
-func (s Sprite) DrawOnGrid(x, y int) {
-	size := s.atlas.spriteSize
-	point := P(x*size.W, y*size.H)
-	s.Draw(point)
+// Fill the whole canvas with the given color.
+//
+// Works the same as calling [ClearScreen] on the canvas
+// but without calling the host.
+func (c Canvas) Fill(color Color) {
+	if color == ColorNone {
+		return
+	}
+	value := byte(color-1) & 0b1111
+	body := c.raw[4:]
+	for i := range body {
+		body[i] = value<<4 | value
+	}
 }
 
 // Fill the whole frame with the given color.
@@ -835,3 +921,8 @@ func UnsetCanvas() {
 func getPtr(s []byte) unsafe.Pointer {
 	return unsafe.Pointer(unsafe.SliceData(s))
 }
+
+// Check if both slices point to the same memory.
+func sameBytes(a, b []byte) bool {
+	return len(a) == len(b) && unsafe.SliceData(a) == unsafe.SliceData(b)
+}
@@ -45,6 +45,27 @@ func TestImage_GetPixel(t *testing.T) {
 	}
 }
 
+func TestSubImage_GetPixel(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	sub := testImage.Image().Sub(P(1, 1), firefly.S(2, 3))
+	tests := []struct {
+		pixel firefly.Point
+		want  firefly.Color
+	}{
+		{pixel: P(-1, 0), want: firefly.ColorNone},
+		{pixel: P(2, 0), want: firefly.ColorNone},
+		{pixel: P(0, 0), want: firefly.ColorLightGreen},
+		{pixel: P(1, 2), want: firefly.ColorGray},
+	}
+	for _, test := range tests {
+		got := sub.GetPixel(test.pixel)
+		if got != test.want {
+			t.Errorf("pixel: {%d, %d}, want %s, but got %s", test.pixel.X, test.pixel.Y, test.want, got)
+		}
+	}
+}
+
 func TestImagePixels(t *testing.T) {
 	t.Parallel()
 	image := testImage.Image()
@@ -54,3 +75,57 @@ func TestImagePixels(t *testing.T) {
 		t.Errorf("want %d, but got %d", want, got)
 	}
 }
+
+func TestCanvas_SetPixel(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	canvas := firefly.NewCanvas(firefly.S(3, 2))
+	canvas.Fill(firefly.ColorBlue)
+	canvas.SetPixel(P(1, 0), firefly.ColorRed)
+	canvas.SetPixel(P(2, 1), firefly.ColorWhite)
+	canvas.SetPixel(P(3, 0), firefly.ColorWhite)
+	canvas.SetPixel(P(0, 1), firefly.ColorNone)
+
+	tests := []struct {
+		pixel firefly.Point
+		want  firefly.Color
+	}{
+		{pixel: P(0, 0), want: firefly.ColorBlue},
+		{pixel: P(1, 0), want: firefly.ColorRed},
+		{pixel: P(2, 0), want: firefly.ColorBlue},
+		{pixel: P(0, 1), want: firefly.ColorBlue},
+		{pixel: P(2, 1), want: firefly.ColorWhite},
+	}
+	for _, test := range tests {
+		got := canvas.GetPixel(test.pixel)
+		if got != test.want {
+			t.Errorf("pixel: {%d, %d}, want %s, but got %s", test.pixel.X, test.pixel.Y, test.want, got)
+		}
+	}
+}
+
+func TestNewCanvas_Size(t *testing.T) {
+	t.Parallel()
+	S := firefly.S
+	tests := []struct {
+		size firefly.Size
+		want firefly.Size
+	}{
+		{size: S(1, 3), want: S(1, 4)},
+		{size: S(1, 4), want: S(1, 4)},
+		{size: S(3, 3), want: S(3, 3)},
+		{size: S(2, 5), want: S(2, 5)},
+		{size: S(5, 1), want: S(5, 1)},
+	}
+	for _, test := range tests {
+		canvas := firefly.NewCanvas(test.size)
+		if got := canvas.Size(); got != test.want {
+			t.Errorf("%v: want %v, but got %v", test.size, test.want, got)
+		}
+		// The size must survive serialization.
+		raw := canvas.Image().Bytes()
+		if got := firefly.UnsafeFileFromBytes(raw).Image().Size(); got != test.want {
+			t.Errorf("%v: want %v after loading, but got %v", test.size, test.want, got)
+		}
+	}
+}
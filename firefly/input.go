@@ -233,7 +233,7 @@ func (p Buttons) JustPressed(old Buttons) Buttons {
 	p.E = p.E && !old.E
 	p.W = p.W && !old.W
 	p.N = p.N && !old.N
-	p.Menu = p.Menu && !old.N
+	p.Menu = p.Menu && !old.Menu
 	return p
 }
 
@@ -0,0 +1,180 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+var ErrInvalidBindings = errors.New("invalid bindings file")
+
+// A named game action, like "jump" or "cancel".
+type Action string
+
+// Inputs for each action.
+type Bindings map[Action][]Binding
+
+// A deep copy of the bindings.
+func (b Bindings) Clone() Bindings {
+	result := make(Bindings, len(b))
+	for action, bindings := range b {
+		result[action] = slices.Clone(bindings)
+	}
+	return result
+}
+
+// Serialize the bindings into text.
+//
+// Each line is an action name followed by its bindings, separated by spaces.
+// See [Binding.String] for the format of bindings.
+func (b Bindings) MarshalText() ([]byte, error) {
+	var buf bytes.Buffer
+	for _, action := range slices.Sorted(maps.Keys(b)) {
+		if strings.ContainsAny(string(action), " \n") {
+			return nil, fmt.Errorf("%w: action name %q has whitespace", ErrInvalidBindings, action)
+		}
+		buf.WriteString(string(action))
+		for _, binding := range b[action] {
+			buf.WriteByte(' ')
+			buf.WriteString(binding.String())
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// Parse bindings serialized by [Bindings.MarshalText].
+func ParseBindings(raw []byte) (Bindings, error) {
+	result := make(Bindings)
+	for i, line := range strings.Split(string(raw), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		bindings := make([]Binding, 0, len(fields)-1)
+		for _, field := range fields[1:] {
+			binding, err := ParseBinding(field)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidBindings, i+1, err)
+			}
+			bindings = append(bindings, binding)
+		}
+		result[Action(fields[0])] = bindings
+	}
+	return result, nil
+}
+
+// Maps actions to inputs for every peer.
+//
+// Instead of checking if the player pressed [firefly.Buttons].S,
+// check if the player pressed the "jump" action. Then players can change
+// which button does what, and the game doesn't need to know about it.
+//
+// Constructed by [NewActions].
+type Actions struct {
+	// The input manager to read the state of peers from.
+	Input *Manager
+
+	// Bindings used for actions that the player didn't rebind.
+	Defaults Bindings
+
+	overrides map[firefly.Peer]Bindings
+}
+
+// Create actions with the given default bindings.
+func NewActions(m *Manager, defaults Bindings) *Actions {
+	return &Actions{
+		Input:     m,
+		Defaults:  defaults,
+		overrides: make(map[firefly.Peer]Bindings),
+	}
+}
+
+// The bindings of the action for the peer.
+func (a *Actions) Bindings(p firefly.Peer, action Action) []Binding {
+	if bindings, found := a.overrides[p][action]; found {
+		return bindings
+	}
+	return a.Defaults[action]
+}
+
+// Replace the bindings of the action for the peer.
+func (a *Actions) Bind(p firefly.Peer, action Action, bindings ...Binding) {
+	overrides, found := a.overrides[p]
+	if !found {
+		overrides = make(Bindings)
+		a.overrides[p] = overrides
+	}
+	overrides[action] = bindings
+}
+
+// Bindings of the peer that are different from the defaults.
+func (a *Actions) Overrides(p firefly.Peer) Bindings {
+	return a.overrides[p]
+}
+
+// Remove all bindings of the peer and use the defaults.
+func (a *Actions) Reset(p firefly.Peer) {
+	delete(a.overrides, p)
+}
+
+// Check if any input bound to the action is active in the state.
+func (a *Actions) active(p firefly.Peer, action Action, s State) bool {
+	for _, b := range a.Bindings(p, action) {
+		if b.Active(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if the action is active on the current frame.
+func (a *Actions) Pressed(p firefly.Peer, action Action) bool {
+	return a.active(p, action, a.Input.Get(p).Current())
+}
+
+// Check if the action wasn't active on the previous frame but is active now.
+func (a *Actions) JustPressed(p firefly.Peer, action Action) bool {
+	t := a.Input.Get(p)
+	return a.active(p, action, t.Current()) && !a.active(p, action, t.Previous())
+}
+
+// Check if the action was active on the previous frame but isn't active now.
+func (a *Actions) JustReleased(p firefly.Peer, action Action) bool {
+	t := a.Input.Get(p)
+	return !a.active(p, action, t.Current()) && a.active(p, action, t.Previous())
+}
+
+// Save the bindings that the peer changed into a file.
+//
+// See [Bindings.MarshalText] for the file format.
+func (a *Actions) Save(p firefly.Peer, path string) error {
+	raw, err := a.Overrides(p).MarshalText()
+	if err != nil {
+		return err
+	}
+	firefly.DumpFile(path, raw)
+	return nil
+}
+
+// Load the bindings saved by [Actions.Save] for the peer.
+//
+// If the file doesn't exist, the peer uses the default bindings.
+func (a *Actions) Load(p firefly.Peer, path string) error {
+	file := firefly.LoadFile(path, nil)
+	if !file.Exists() {
+		a.Reset(p)
+		return nil
+	}
+	bindings, err := ParseBindings(file.Bytes())
+	if err != nil {
+		return err
+	}
+	a.overrides[p] = bindings
+	return nil
+}
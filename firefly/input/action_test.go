@@ -0,0 +1,134 @@
+package input_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/input"
+)
+
+const (
+	jump   input.Action = "jump"
+	cancel input.Action = "cancel"
+)
+
+func newActions() (*input.Actions, *input.Tracker) {
+	m := input.New()
+	actions := input.NewActions(m, input.Bindings{
+		jump:   {input.BindButton(input.ButtonS), input.BindDPad(firefly.DPad4Up)},
+		cancel: {input.BindButton(input.ButtonE)},
+	})
+	return actions, m.Get(firefly.Combined)
+}
+
+func TestActions(t *testing.T) {
+	t.Parallel()
+	a, tr := newActions()
+	p := firefly.Combined
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	if !a.JustPressed(p, jump) || a.Pressed(p, cancel) {
+		t.Errorf("jump must be just pressed")
+	}
+	// Switching from one binding to another doesn't press the action again.
+	tr.Push(input.State{Touched: true, Pad: firefly.Pad{Y: 900}})
+	if !a.Pressed(p, jump) || a.JustPressed(p, jump) {
+		t.Errorf("jump must be held")
+	}
+	tr.Push(input.State{})
+	if !a.JustReleased(p, jump) {
+		t.Errorf("jump must be just released")
+	}
+
+	a.Bind(p, jump, input.BindButton(input.ButtonN))
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	if a.Pressed(p, jump) {
+		t.Errorf("the default binding must be overridden")
+	}
+	a.Reset(p)
+	if !a.Pressed(p, jump) {
+		t.Errorf("the default binding must be restored")
+	}
+}
+
+func TestRebinder(t *testing.T) {
+	t.Parallel()
+	a, tr := newActions()
+	p := firefly.Combined
+	r := a.Rebind(p, jump)
+	tr.Push(input.State{})
+	if r.Update() {
+		t.Fatalf("nothing must be captured without input")
+	}
+	tr.Push(input.State{Buttons: firefly.Buttons{E: true}})
+	if !r.Update() {
+		t.Fatalf("E must be captured")
+	}
+	if got := a.Bindings(p, jump); len(got) != 1 || got[0] != input.BindButton(input.ButtonE) {
+		t.Errorf("jump must be bound to E, got %v", got)
+	}
+	if got := a.Bindings(p, cancel); len(got) != 0 {
+		t.Errorf("E must be removed from cancel, got %v", got)
+	}
+	if len(a.Defaults[cancel]) != 1 {
+		t.Errorf("the defaults must not change")
+	}
+}
+
+func TestRebinder_Held(t *testing.T) {
+	t.Parallel()
+	a, tr := newActions()
+	p := firefly.Combined
+	// The button that opened the rebinder is pressed on the same frame.
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	r := a.Rebind(p, cancel)
+	if r.Update() {
+		t.Fatalf("the button pressed before the rebinder must not be captured")
+	}
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	if r.Update() {
+		t.Fatalf("the held button must not be captured")
+	}
+	tr.Push(input.State{})
+	if r.Update() {
+		t.Fatalf("nothing must be captured without input")
+	}
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	if !r.Update() {
+		t.Fatalf("S must be captured after it was released")
+	}
+	if got := a.Bindings(p, cancel); len(got) != 1 || got[0] != input.BindButton(input.ButtonS) {
+		t.Errorf("cancel must be bound to S, got %v", got)
+	}
+}
+
+func TestParseBindings(t *testing.T) {
+	t.Parallel()
+	bindings := input.Bindings{
+		jump: {
+			input.BindButton(input.ButtonS),
+			input.BindDPad(firefly.DPad4Left),
+			input.BindRegion(firefly.Pad{X: -1000, Y: 0}, firefly.Pad{X: 0, Y: 1000}),
+		},
+		cancel: {},
+	}
+	raw, err := bindings.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "cancel\njump S left region:-1000:0:0:1000\n"
+	if string(raw) != want {
+		t.Errorf("want %q, got %q", want, raw)
+	}
+	parsed, err := input.ParseBindings(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(parsed) != 2 || len(parsed[jump]) != 3 || parsed[jump][2] != bindings[jump][2] {
+		t.Errorf("wrong parsed bindings: %v", parsed)
+	}
+	_, err = input.ParseBindings([]byte("jump X"))
+	if !errors.Is(err, input.ErrInvalidBinding) {
+		t.Errorf("want ErrInvalidBinding, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// How to merge the touch pads of all peers into one, see [Aggregation].
+type PadAggregation uint8
+
+const (
+	// The average position of all peers touching the pad.
+	//
+	// Good for co-op games where everyone steers the same character.
+	PadAverage PadAggregation = iota
+	// The [firefly.DPad4] direction chosen by the majority of peers, see [VoteDPad4].
+	//
+	// The resulting pad points to the edge in that direction.
+	// If there is no majority, the pad isn't touched.
+	PadVote
+)
+
+// A strategy of merging the input of all peers into one.
+//
+// Unlike [firefly.Combined] which merges the input in the runtime,
+// it's computed in Go from the input of every peer.
+// See [Manager.Aggregated].
+type Aggregation struct {
+	// If true, a button is pressed only when all peers press it.
+	// Otherwise, it's pressed when any peer presses it.
+	//
+	// Useful for "everyone press S to continue".
+	AllButtons bool
+
+	// How to merge the touch pads.
+	Pad PadAggregation
+}
+
+// Merge the states of all peers into one.
+//
+// If there are no states, the result is empty.
+func (a Aggregation) Apply(states []State) State {
+	if len(states) == 0 {
+		return State{}
+	}
+	var result State
+	if a.AllButtons {
+		result.Buttons = AllButtons(states)
+	} else {
+		result.Buttons = AnyButtons(states)
+	}
+	switch a.Pad {
+	case PadAverage:
+		result.Pad, result.Touched = AveragePad(states)
+	case PadVote:
+		dir := VoteDPad4(states)
+		result.Pad, result.Touched = dpadToPad(dir), dir != firefly.DPad4None
+	}
+	return result
+}
+
+// Buttons pressed by at least one peer.
+func AnyButtons(states []State) firefly.Buttons {
+	var result firefly.Buttons
+	for _, s := range states {
+		b := s.Buttons
+		result.S = result.S || b.S
+		result.E = result.E || b.E
+		result.W = result.W || b.W
+		result.N = result.N || b.N
+		result.Menu = result.Menu || b.Menu
+	}
+	return result
+}
+
+// Buttons pressed by all peers.
+//
+// If there are no states, no buttons are pressed.
+func AllButtons(states []State) firefly.Buttons {
+	if len(states) == 0 {
+		return firefly.Buttons{}
+	}
+	result := firefly.Buttons{S: true, E: true, W: true, N: true, Menu: true}
+	for _, s := range states {
+		b := s.Buttons
+		result.S = result.S && b.S
+		result.E = result.E && b.E
+		result.W = result.W && b.W
+		result.N = result.N && b.N
+		result.Menu = result.Menu && b.Menu
+	}
+	return result
+}
+
+// The [firefly.DPad4] direction chosen by more than half of the voting peers.
+//
+// Peers that don't touch the pad or touch its center don't vote.
+// If no direction has the majority or nobody voted, returns [firefly.DPad4None].
+func VoteDPad4(states []State) firefly.DPad4 {
+	var votes [5]int
+	voters := 0
+	for _, s := range states {
+		dir := dpad4(s)
+		if dir != firefly.DPad4None {
+			votes[dir]++
+			voters++
+		}
+	}
+	dirs := []firefly.DPad4{firefly.DPad4Right, firefly.DPad4Up, firefly.DPad4Left, firefly.DPad4Down}
+	for _, dir := range dirs {
+		if votes[dir]*2 > voters {
+			return dir
+		}
+	}
+	return firefly.DPad4None
+}
+
+// The average pad position of all peers touching the pad.
+//
+// Returns false if nobody touches the pad.
+func AveragePad(states []State) (firefly.Pad, bool) {
+	var sum firefly.Pad
+	count := 0
+	for _, s := range states {
+		if s.Touched {
+			sum.X += s.Pad.X
+			sum.Y += s.Pad.Y
+			count++
+		}
+	}
+	if count == 0 {
+		return firefly.Pad{}, false
+	}
+	return firefly.Pad{X: sum.X / count, Y: sum.Y / count}, true
+}
+
+// The pad position at the edge in the given direction.
+func dpadToPad(d firefly.DPad4) firefly.Pad {
+	switch d {
+	case firefly.DPad4Right:
+		return firefly.Pad{X: firefly.PadMaxX}
+	case firefly.DPad4Up:
+		return firefly.Pad{Y: firefly.PadMaxY}
+	case firefly.DPad4Left:
+		return firefly.Pad{X: firefly.PadMinX}
+	case firefly.DPad4Down:
+		return firefly.Pad{Y: firefly.PadMinY}
+	case firefly.DPad4None:
+		return firefly.Pad{}
+	}
+	return firefly.Pad{}
+}
+
+// Tracks which peers have pressed a button, like "press S when you're ready".
+//
+// Constructed by [NewReadyCheck].
+type ReadyCheck struct {
+	// The button the peers need to press.
+	Button Button
+
+	ready map[firefly.Peer]bool
+}
+
+// Create a ready check for the given button.
+func NewReadyCheck(b Button) *ReadyCheck {
+	return &ReadyCheck{Button: b, ready: make(map[firefly.Peer]bool)}
+}
+
+// Mark peers that just pressed the button as ready.
+//
+// Should be called on every update after [Manager.Update].
+// Peers that went offline are forgotten.
+func (r *ReadyCheck) Update(m *Manager) {
+	online := make(map[firefly.Peer]bool, len(m.peers))
+	for _, p := range m.peers {
+		online[p] = true
+		if r.Button.In(m.Get(p).JustPressed()) {
+			r.ready[p] = true
+		}
+	}
+	for p := range r.ready {
+		if !online[p] {
+			delete(r.ready, p)
+		}
+	}
+}
+
+// Check if the peer has pressed the button.
+func (r *ReadyCheck) Ready(p firefly.Peer) bool {
+	return r.ready[p]
+}
+
+// How many peers have pressed the button.
+func (r *ReadyCheck) Count() int {
+	return len(r.ready)
+}
+
+// Online peers that haven't pressed the button yet.
+func (r *ReadyCheck) Waiting(m *Manager) []firefly.Peer {
+	var result []firefly.Peer
+	for _, p := range m.peers {
+		if !r.ready[p] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Check if all online peers have pressed the button.
+func (r *ReadyCheck) Done(m *Manager) bool {
+	return len(m.peers) > 0 && len(r.Waiting(m)) == 0
+}
+
+// Forget who has pressed the button, to start a new check.
+func (r *ReadyCheck) Reset() {
+	clear(r.ready)
+}
@@ -0,0 +1,86 @@
+package input_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/input"
+)
+
+func TestAggregation(t *testing.T) {
+	t.Parallel()
+	states := []input.State{
+		{Buttons: firefly.Buttons{S: true, E: true}, Touched: true, Pad: firefly.Pad{X: 900, Y: 100}},
+		{Buttons: firefly.Buttons{S: true}, Touched: true, Pad: firefly.Pad{X: 700, Y: -300}},
+		{Buttons: firefly.Buttons{S: true, N: true}, Touched: true, Pad: firefly.Pad{X: -100, Y: 800}},
+		{Buttons: firefly.Buttons{S: true}},
+	}
+	if got := input.AnyButtons(states); got != (firefly.Buttons{S: true, E: true, N: true}) {
+		t.Errorf("wrong any buttons: %+v", got)
+	}
+	if got := input.AllButtons(states); got != (firefly.Buttons{S: true}) {
+		t.Errorf("wrong all buttons: %+v", got)
+	}
+	if got := input.VoteDPad4(states); got != firefly.DPad4Right {
+		t.Errorf("want right, got %d", got)
+	}
+	if got := input.VoteDPad4(states[1:]); got != firefly.DPad4None {
+		t.Errorf("a tie must be none, got %d", got)
+	}
+	plurality := []input.State{
+		{Touched: true, Pad: firefly.Pad{X: 900}},
+		{Touched: true, Pad: firefly.Pad{X: 900}},
+		{Touched: true, Pad: firefly.Pad{Y: 900}},
+		{Touched: true, Pad: firefly.Pad{X: -900}},
+		{Touched: true, Pad: firefly.Pad{Y: -900}},
+	}
+	if got := input.VoteDPad4(plurality); got != firefly.DPad4None {
+		t.Errorf("a direction without the majority must be none, got %d", got)
+	}
+	pad, touched := input.AveragePad(states)
+	if !touched || pad != (firefly.Pad{X: 500, Y: 200}) {
+		t.Errorf("wrong average pad: %v", pad)
+	}
+
+	vote := input.Aggregation{AllButtons: true, Pad: input.PadVote}
+	want := input.State{Buttons: firefly.Buttons{S: true}, Touched: true, Pad: firefly.Pad{X: 1000}}
+	if got := vote.Apply(states); got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+	if got := vote.Apply(nil); got != (input.State{}) {
+		t.Errorf("no states must give an empty state, got %+v", got)
+	}
+}
+
+func TestReadyCheck(t *testing.T) {
+	t.Parallel()
+	m := input.New()
+	a, b := firefly.Peer{}, firefly.Combined
+	m.SetPeers(a, b)
+	r := input.NewReadyCheck(input.ButtonS)
+	m.Get(a).Push(input.State{Buttons: firefly.Buttons{S: true}})
+	m.Get(b).Push(input.State{Buttons: firefly.Buttons{E: true}})
+	r.Update(m)
+	if !r.Ready(a) || r.Ready(b) || r.Done(m) {
+		t.Fatalf("only the first peer must be ready")
+	}
+	if got := r.Waiting(m); !slices.Equal(got, []firefly.Peer{b}) {
+		t.Errorf("wrong waiting peers: %v", got)
+	}
+	m.Get(b).Push(input.State{Buttons: firefly.Buttons{S: true}})
+	r.Update(m)
+	if !r.Done(m) || r.Count() != 2 {
+		t.Errorf("all peers must be ready")
+	}
+	// The peer that went offline is forgotten.
+	m.SetPeers(a)
+	r.Update(m)
+	if r.Ready(b) || r.Count() != 1 {
+		t.Errorf("offline peers must be forgotten")
+	}
+	r.Reset()
+	if r.Done(m) {
+		t.Errorf("nobody must be ready after reset")
+	}
+}
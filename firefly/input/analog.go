@@ -0,0 +1,263 @@
+package input
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+// A 2D vector with both coordinates in the range from -1 to 1.
+//
+// Like with [firefly.Pad], positive X is on the right and positive Y is on the top.
+type Vec struct {
+	X float32
+	Y float32
+}
+
+// The length of the vector.
+func (v Vec) Len() float32 {
+	return tinymath.Hypot(v.X, v.Y)
+}
+
+// Multiply both coordinates by the factor.
+func (v Vec) Scale(f float32) Vec {
+	return Vec{X: v.X * f, Y: v.Y * f}
+}
+
+// A response curve maps the input magnitude in the range from 0 to 1
+// into the output magnitude in the same range.
+type Curve func(x float32) float32
+
+// The output is the same as the input.
+func Linear(x float32) float32 {
+	return x
+}
+
+// Small movements are more precise, large movements are faster.
+func Quadratic(x float32) float32 {
+	return x * x
+}
+
+// Like [Quadratic] but even more precise for small movements.
+func Cubic(x float32) float32 {
+	return x * x * x
+}
+
+// The shape of the dead zone.
+type DeadZone uint8
+
+const (
+	// The dead zone is a circle: the distance from the center is what matters.
+	//
+	// Good for free movement in all directions.
+	DeadZoneRadial DeadZone = iota
+	// The dead zone is a cross: each axis is processed separately.
+	//
+	// Good for snapping the movement to axes, like in platformers.
+	DeadZoneAxial
+)
+
+// Settings for [Analog].
+type AnalogConfig struct {
+	// The shape of the dead zones.
+	DeadZone DeadZone
+
+	// Values closer to the center than this are zero.
+	//
+	// In the range from 0 to 1, as a fraction of the pad radius.
+	Inner float32
+
+	// Values further from the center than this are one.
+	//
+	// In the range from 0 to 1, as a fraction of the pad radius.
+	// Must be greater than [AnalogConfig.Inner].
+	Outer float32
+
+	// The response curve applied after the dead zones. Nil is [Linear].
+	Curve Curve
+
+	// How much of the previous value is kept on each update, from 0 to 1.
+	//
+	// Zero means no smoothing, higher values make the movement smoother but laggier.
+	Smoothing float32
+
+	// The minimum processed X or Y value for a [firefly.DPad4] direction to be pressed.
+	DPad4Threshold float32
+
+	// The minimum processed X or Y value for a [firefly.DPad8] direction to be pressed.
+	DPad8Threshold float32
+
+	// How much lower than the threshold the value must go to release a pressed direction.
+	//
+	// Prevents directions from flickering when the finger is at the boundary.
+	Hysteresis float32
+}
+
+// The default settings for [Analog].
+//
+// The DPad thresholds are close to the ones of [firefly.Pad.DPad4] and [firefly.Pad.DPad8].
+func DefaultAnalogConfig() AnalogConfig {
+	return AnalogConfig{
+		DeadZone:       DeadZoneRadial,
+		Inner:          0.1,
+		Outer:          0.95,
+		Curve:          Linear,
+		DPad4Threshold: 0.25,
+		DPad8Threshold: 0.35,
+		Hysteresis:     0.1,
+	}
+}
+
+// Processes the touch pad input as an analog stick.
+//
+// Constructed by [NewAnalog].
+type Analog struct {
+	Config AnalogConfig
+
+	value Vec
+	dpad4 firefly.DPad4
+	dpad8 firefly.DPad8
+}
+
+// Create an analog stick processor with the given settings.
+func NewAnalog(config AnalogConfig) *Analog {
+	return &Analog{Config: config}
+}
+
+// Process the state for a new frame and return the processed value.
+//
+// Should be called on every update.
+func (a *Analog) Update(s State) Vec {
+	if !s.Touched {
+		a.Reset()
+		return a.value
+	}
+	raw := Vec{X: float32(s.Pad.X) / firefly.PadMaxX, Y: float32(s.Pad.Y) / firefly.PadMaxY}
+	var value Vec
+	switch a.Config.DeadZone {
+	case DeadZoneRadial:
+		r := raw.Len()
+		if r > 0 {
+			value = raw.Scale(a.magnitude(r) / r)
+		}
+	case DeadZoneAxial:
+		value = Vec{X: a.axis(raw.X), Y: a.axis(raw.Y)}
+	}
+	k := a.Config.Smoothing
+	a.value = Vec{
+		X: a.value.X*k + value.X*(1-k),
+		Y: a.value.Y*k + value.Y*(1-k),
+	}
+	a.dpad8 = a.updateDPad8()
+	a.dpad4 = a.updateDPad4()
+	return a.value
+}
+
+// Forget the state, as if the pad was released.
+func (a *Analog) Reset() {
+	a.value = Vec{}
+	a.dpad4 = firefly.DPad4None
+	a.dpad8 = firefly.DPad8{}
+}
+
+// The processed value.
+func (a *Analog) Value() Vec {
+	return a.value
+}
+
+// The processed value as [firefly.DPad4].
+func (a *Analog) DPad4() firefly.DPad4 {
+	return a.dpad4
+}
+
+// The processed value as [firefly.DPad8].
+func (a *Analog) DPad8() firefly.DPad8 {
+	return a.dpad8
+}
+
+// Apply the dead zones and the response curve to the distance from the center.
+func (a *Analog) magnitude(r float32) float32 {
+	inner := a.Config.Inner
+	outer := a.Config.Outer
+	if r <= inner {
+		return 0
+	}
+	if r >= outer {
+		return 1
+	}
+	x := (r - inner) / (outer - inner)
+	if a.Config.Curve != nil {
+		x = a.Config.Curve(x)
+	}
+	return min(max(x, 0), 1)
+}
+
+// Apply the dead zones and the response curve to a single axis.
+func (a *Analog) axis(v float32) float32 {
+	if v < 0 {
+		return -a.magnitude(-v)
+	}
+	return a.magnitude(v)
+}
+
+func (a *Analog) updateDPad8() firefly.DPad8 {
+	t := a.Config.DPad8Threshold
+	h := a.Config.Hysteresis
+	v := a.value
+	pressed := func(value float32, was bool) bool {
+		if was {
+			return value >= t-h
+		}
+		return value >= t
+	}
+	return firefly.DPad8{
+		Left:  pressed(-v.X, a.dpad8.Left),
+		Right: pressed(v.X, a.dpad8.Right),
+		Up:    pressed(v.Y, a.dpad8.Up),
+		Down:  pressed(-v.Y, a.dpad8.Down),
+	}
+}
+
+func (a *Analog) updateDPad4() firefly.DPad4 {
+	t := a.Config.DPad4Threshold
+	h := a.Config.Hysteresis
+	v := a.value
+	// The pressed direction stays pressed until the value goes
+	// below the threshold or the other axis clearly dominates.
+	if a.dpad4 != firefly.DPad4None {
+		along, across := axes(v, a.dpad4)
+		if along >= t-h && along+h >= across {
+			return a.dpad4
+		}
+	}
+	absX := abs32(v.X)
+	absY := abs32(v.Y)
+	switch {
+	case v.Y >= t && v.Y > absX:
+		return firefly.DPad4Up
+	case -v.Y >= t && -v.Y > absX:
+		return firefly.DPad4Down
+	case v.X >= t && v.X > absY:
+		return firefly.DPad4Right
+	case -v.X >= t && -v.X > absY:
+		return firefly.DPad4Left
+	default:
+		return firefly.DPad4None
+	}
+}
+
+// The value along the direction and the absolute value across it.
+func axes(v Vec, d firefly.DPad4) (float32, float32) {
+	switch d {
+	case firefly.DPad4Right:
+		return v.X, abs32(v.Y)
+	case firefly.DPad4Left:
+		return -v.X, abs32(v.Y)
+	case firefly.DPad4Up:
+		return v.Y, abs32(v.X)
+	case firefly.DPad4Down:
+		return -v.Y, abs32(v.X)
+	case firefly.DPad4None:
+		return 0, 0
+	}
+	return 0, 0
+}
@@ -0,0 +1,96 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/input"
+)
+
+func touch(x, y int) input.State {
+	return input.State{Touched: true, Pad: firefly.Pad{X: x, Y: y}}
+}
+
+func near(a, b float32) bool {
+	d := a - b
+	return d < 0.001 && d > -0.001
+}
+
+func TestAnalog_DeadZone(t *testing.T) {
+	t.Parallel()
+	radial := input.DefaultAnalogConfig()
+	axial := radial
+	axial.DeadZone = input.DeadZoneAxial
+	quadratic := radial
+	quadratic.Curve = input.Quadratic
+	tests := []struct {
+		name   string
+		config input.AnalogConfig
+		state  input.State
+		want   input.Vec
+	}{
+		{"released", radial, input.State{}, input.Vec{}},
+		{"inner", radial, touch(50, 50), input.Vec{}},
+		{"outer", radial, touch(0, -980), input.Vec{Y: -1}},
+		{"half", radial, touch(525, 0), input.Vec{X: 0.5}},
+		{"curve", quadratic, touch(-525, 0), input.Vec{X: -0.25}},
+		{"radial diagonal", radial, touch(1000, 80), input.Vec{X: 0.996, Y: 0.080}},
+		{"axial diagonal", axial, touch(1000, 80), input.Vec{X: 1}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := input.NewAnalog(tc.config).Update(tc.state)
+			if !near(got.X, tc.want.X) || !near(got.Y, tc.want.Y) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAnalog_Smoothing(t *testing.T) {
+	t.Parallel()
+	config := input.DefaultAnalogConfig()
+	config.Smoothing = 0.5
+	a := input.NewAnalog(config)
+	if got := a.Update(touch(1000, 0)); !near(got.X, 0.5) {
+		t.Errorf("want 0.5, got %f", got.X)
+	}
+	if got := a.Update(touch(1000, 0)); !near(got.X, 0.75) {
+		t.Errorf("want 0.75, got %f", got.X)
+	}
+	if got := a.Update(input.State{}); got != (input.Vec{}) {
+		t.Errorf("releasing the pad must reset the value, got %v", got)
+	}
+}
+
+func TestAnalog_Hysteresis(t *testing.T) {
+	t.Parallel()
+	a := input.NewAnalog(input.DefaultAnalogConfig())
+	a.Update(touch(406, 0))
+	if !a.DPad8().Right || a.DPad4() != firefly.DPad4Right {
+		t.Fatalf("right must be pressed")
+	}
+	// Below the threshold but within the hysteresis.
+	a.Update(touch(355, 0))
+	if !a.DPad8().Right || a.DPad4() != firefly.DPad4Right {
+		t.Errorf("right must stay pressed")
+	}
+	// The diagonal where up slightly dominates.
+	a.Update(touch(500, 520))
+	if a.DPad4() != firefly.DPad4Right {
+		t.Errorf("right must stay pressed on the diagonal")
+	}
+	a.Update(touch(400, 700))
+	if a.DPad4() != firefly.DPad4Up {
+		t.Errorf("up must be pressed")
+	}
+	a.Update(touch(300, 0))
+	if a.DPad8().Right {
+		t.Errorf("right must be released")
+	}
+	a.Update(touch(355, 0))
+	if a.DPad8().Right {
+		t.Errorf("right must not be pressed again below the threshold")
+	}
+}
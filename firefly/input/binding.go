@@ -0,0 +1,122 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+var ErrInvalidBinding = errors.New("invalid binding")
+
+// The kind of input a [Binding] reacts to.
+type BindingKind uint8
+
+const (
+	// A button, see [BindButton].
+	BindingButton BindingKind = iota + 1
+	// A direction of [firefly.DPad4], see [BindDPad].
+	BindingDPad
+	// A rectangular region of the touch pad, see [BindRegion].
+	BindingRegion
+)
+
+// An input that triggers an [Action].
+type Binding struct {
+	Kind BindingKind
+
+	// The button for [BindingButton].
+	Button Button
+
+	// The direction for [BindingDPad].
+	Direction firefly.DPad4
+
+	// The corners of the region for [BindingRegion], both included.
+	Min firefly.Pad
+	Max firefly.Pad
+}
+
+// Bind a button.
+func BindButton(b Button) Binding {
+	return Binding{Kind: BindingButton, Button: b}
+}
+
+// Bind a pad direction.
+func BindDPad(d firefly.DPad4) Binding {
+	return Binding{Kind: BindingDPad, Direction: d}
+}
+
+// Bind a region of the touch pad.
+//
+// The action is active while the pad is touched inside of the region.
+func BindRegion(minPad, maxPad firefly.Pad) Binding {
+	return Binding{Kind: BindingRegion, Min: minPad, Max: maxPad}
+}
+
+// Check if the input is active in the given state.
+func (b Binding) Active(s State) bool {
+	switch b.Kind {
+	case BindingButton:
+		return b.Button.In(s.Buttons)
+	case BindingDPad:
+		return s.Touched && s.Pad.DPad4() == b.Direction
+	case BindingRegion:
+		p := s.Pad
+		return s.Touched && p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+	}
+	return false
+}
+
+var dpadNames = map[firefly.DPad4]string{
+	firefly.DPad4Left:  "left",
+	firefly.DPad4Right: "right",
+	firefly.DPad4Up:    "up",
+	firefly.DPad4Down:  "down",
+}
+
+// The text representation of the binding, the same as used in saved bindings.
+//
+// Buttons are "S", "E", "W", "N", directions are "left", "right", "up", "down",
+// and regions are "region:minX:minY:maxX:maxY".
+func (b Binding) String() string {
+	switch b.Kind {
+	case BindingButton:
+		return b.Button.String()
+	case BindingDPad:
+		return dpadNames[b.Direction]
+	case BindingRegion:
+		return fmt.Sprintf("region:%d:%d:%d:%d", b.Min.X, b.Min.Y, b.Max.X, b.Max.Y)
+	}
+	return "?"
+}
+
+// Parse a binding from its [Binding.String] representation.
+func ParseBinding(s string) (Binding, error) {
+	for b := range Button(buttonCount) {
+		if b.String() == s {
+			return BindButton(b), nil
+		}
+	}
+	for d, name := range dpadNames {
+		if name == s {
+			return BindDPad(d), nil
+		}
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) != 5 || parts[0] != "region" {
+		return Binding{}, fmt.Errorf("%w: %q", ErrInvalidBinding, s)
+	}
+	var values [4]int
+	for i, part := range parts[1:] {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return Binding{}, fmt.Errorf("%w: %q: %w", ErrInvalidBinding, s, err)
+		}
+		values[i] = v
+	}
+	minPad := firefly.Pad{X: values[0], Y: values[1]}
+	maxPad := firefly.Pad{X: values[2], Y: values[3]}
+	return BindRegion(minPad, maxPad), nil
+}
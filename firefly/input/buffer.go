@@ -0,0 +1,128 @@
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// How many input events are kept for each peer by default.
+const DefaultBufferSize = 32
+
+// A change of the input on a single frame.
+type Event struct {
+	// The number of the frame on which the event happened, see [Buffer.Frame].
+	Frame int
+
+	// The pad direction on that frame.
+	Direction firefly.DPad8
+
+	// True if the direction is different from the previous frame.
+	Turned bool
+
+	// Buttons that were just pressed on that frame.
+	Pressed firefly.Buttons
+}
+
+// A ring buffer of timestamped input events.
+//
+// Only frames on which the player pressed a button or changed
+// the pad direction are recorded. It's used for input buffering
+// (see [Buffer.Pressed]) and for detecting combos (see [Buffer.Match]).
+//
+// Obtained from [Tracker.Buffer] or constructed by [NewBuffer].
+type Buffer struct {
+	events []Event
+	head   int
+	count  int
+
+	frame     int
+	direction firefly.DPad8
+	buttons   firefly.Buttons
+}
+
+// Create a buffer that keeps the given number of the latest events.
+func NewBuffer(size int) *Buffer {
+	return &Buffer{events: make([]Event, max(size, 1))}
+}
+
+// Add the state for a new frame.
+//
+// Called by [Tracker.Push].
+func (b *Buffer) Push(s State) {
+	b.frame++
+	direction := dpad8(s)
+	event := Event{
+		Frame:     b.frame,
+		Direction: direction,
+		Turned:    direction != b.direction,
+		Pressed:   s.Buttons.JustPressed(b.buttons),
+	}
+	b.direction = direction
+	b.buttons = s.Buttons
+	if !event.Turned && !event.Pressed.Any() {
+		return
+	}
+	b.head = (b.head + 1) % len(b.events)
+	b.events[b.head] = event
+	b.count = min(b.count+1, len(b.events))
+}
+
+// The number of the current frame.
+//
+// Starts at zero and increases by one on every [Buffer.Push].
+func (b *Buffer) Frame() int {
+	return b.frame
+}
+
+// The number of kept events.
+func (b *Buffer) Len() int {
+	return b.count
+}
+
+// The event at the given index, zero being the latest one.
+//
+// Returns an empty event if the index is out of range.
+func (b *Buffer) Event(i int) Event {
+	if i < 0 || i >= b.count {
+		return Event{}
+	}
+	return b.events[(b.head-i+len(b.events))%len(b.events)]
+}
+
+// Check if the button was pressed during the given number of the latest frames.
+//
+// This is input buffering: if the player presses jump a few frames
+// before the character lands, the jump still happens when it lands.
+// Call [Buffer.Consume] after acting on the press,
+// so that the same press doesn't trigger the action again.
+//
+// With frames set to 1, it's the same as checking if the button was just pressed.
+func (b *Buffer) Pressed(btn Button, frames int) bool {
+	for i := range b.count {
+		e := b.Event(i)
+		if b.frame-e.Frame >= frames {
+			return false
+		}
+		if btn.In(e.Pressed) {
+			return true
+		}
+	}
+	return false
+}
+
+// Forget the latest press of the button, so that [Buffer.Pressed] doesn't report it again.
+//
+// Consumed presses are also ignored by [Buffer.Match].
+func (b *Buffer) Consume(btn Button) {
+	for i := range b.count {
+		e := &b.events[(b.head-i+len(b.events))%len(b.events)]
+		if btn.In(e.Pressed) {
+			e.Pressed = btn.remove(e.Pressed)
+			return
+		}
+	}
+}
+
+// Forget all events.
+//
+// Useful when the character gets hit or the scene changes.
+func (b *Buffer) Clear() {
+	b.count = 0
+}
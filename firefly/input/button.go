@@ -0,0 +1,68 @@
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// A single button of [firefly.Buttons].
+type Button uint8
+
+const (
+	ButtonS Button = iota
+	ButtonE
+	ButtonW
+	ButtonN
+	ButtonMenu
+)
+
+// How many different buttons there are.
+const buttonCount = 5
+
+// Check if the button is pressed in the given state.
+func (b Button) In(buttons firefly.Buttons) bool {
+	switch b {
+	case ButtonS:
+		return buttons.S
+	case ButtonE:
+		return buttons.E
+	case ButtonW:
+		return buttons.W
+	case ButtonN:
+		return buttons.N
+	case ButtonMenu:
+		return buttons.Menu
+	}
+	return false
+}
+
+// The name of the button.
+func (b Button) String() string {
+	switch b {
+	case ButtonS:
+		return "S"
+	case ButtonE:
+		return "E"
+	case ButtonW:
+		return "W"
+	case ButtonN:
+		return "N"
+	case ButtonMenu:
+		return "Menu"
+	}
+	return "?"
+}
+
+// The buttons with the button released.
+func (b Button) remove(buttons firefly.Buttons) firefly.Buttons {
+	switch b {
+	case ButtonS:
+		buttons.S = false
+	case ButtonE:
+		buttons.E = false
+	case ButtonW:
+		buttons.W = false
+	case ButtonN:
+		buttons.N = false
+	case ButtonMenu:
+		buttons.Menu = false
+	}
+	return buttons
+}
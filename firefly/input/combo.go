@@ -0,0 +1,100 @@
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// A single step of a [Combo].
+type Step struct {
+	// The pad direction that must be entered on this step.
+	//
+	// If no directions are set, the direction doesn't matter
+	// and the step must have buttons.
+	Direction firefly.DPad8
+
+	// Buttons that must be pressed on the same frame.
+	//
+	// If empty, the step is matched when the player turns the pad into the direction.
+	Buttons []Button
+}
+
+// A step that is matched when the pad is turned into the direction.
+func Dir(d firefly.DPad8) Step {
+	return Step{Direction: d}
+}
+
+// A step that is matched when all the buttons are pressed on the same frame.
+func Press(buttons ...Button) Step {
+	return Step{Buttons: buttons}
+}
+
+// The same step but also requiring the buttons to be pressed.
+//
+// For example, Dir(right).With(ButtonW) is "Right + W":
+// pressing W while the pad points to the right.
+func (s Step) With(buttons ...Button) Step {
+	s.Buttons = append(s.Buttons[:len(s.Buttons):len(s.Buttons)], buttons...)
+	return s
+}
+
+// Check if the event matches the step.
+func (s Step) match(e Event) bool {
+	if s.Direction.Any() && e.Direction != s.Direction {
+		return false
+	}
+	if len(s.Buttons) == 0 {
+		return s.Direction.Any() && e.Turned
+	}
+	for _, b := range s.Buttons {
+		if !b.In(e.Pressed) {
+			return false
+		}
+	}
+	return true
+}
+
+// A sequence of inputs, like "Down, Down-Right, Right + W".
+type Combo struct {
+	Steps []Step
+
+	// The maximum number of frames between the first and the last step.
+	//
+	// If zero, the combo must fit into [DefaultComboWindow] frames.
+	Window int
+}
+
+// How many frames a combo may take if [Combo.Window] isn't set.
+const DefaultComboWindow = 30
+
+// Check if the combo is completed on the current frame.
+//
+// The last step must happen on the current frame, so the combo is reported only once.
+// The steps must happen in order but there may be other inputs between them.
+func (b *Buffer) Match(c Combo) bool {
+	if len(c.Steps) == 0 {
+		return false
+	}
+	window := c.Window
+	if window == 0 {
+		window = DefaultComboWindow
+	}
+	last := b.Event(0)
+	if b.count == 0 || last.Frame != b.frame {
+		return false
+	}
+	step := len(c.Steps) - 1
+	for i := range b.count {
+		e := b.Event(i)
+		if last.Frame-e.Frame > window {
+			return false
+		}
+		if i == 0 && !c.Steps[step].match(e) {
+			return false
+		}
+		if c.Steps[step].match(e) {
+			step--
+			if step < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
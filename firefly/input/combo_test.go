@@ -0,0 +1,100 @@
+package input_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/input"
+)
+
+var (
+	padDown      = firefly.Pad{Y: -900}
+	padDownRight = firefly.Pad{X: 900, Y: -900}
+	padRight     = firefly.Pad{X: 900}
+)
+
+func TestBuffer_Pressed(t *testing.T) {
+	t.Parallel()
+	b := input.NewBuffer(input.DefaultBufferSize)
+	b.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	b.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	b.Push(input.State{})
+	if b.Len() != 1 || b.Frame() != 3 {
+		t.Fatalf("only one event must be recorded, got %d", b.Len())
+	}
+	if !b.Pressed(input.ButtonS, 3) || b.Pressed(input.ButtonS, 2) {
+		t.Errorf("S must be pressed exactly 3 frames ago")
+	}
+	b.Consume(input.ButtonS)
+	if b.Pressed(input.ButtonS, 3) {
+		t.Errorf("consumed press must be ignored")
+	}
+}
+
+func TestBuffer_Match(t *testing.T) {
+	t.Parallel()
+	combo := input.Combo{
+		Steps: []input.Step{
+			input.Dir(firefly.DPad8{Down: true}),
+			input.Dir(firefly.DPad8{Down: true, Right: true}),
+			input.Dir(firefly.DPad8{Right: true}).With(input.ButtonW),
+		},
+		Window: 10,
+	}
+	w := firefly.Buttons{W: true}
+	tests := []struct {
+		name   string
+		states []input.State
+		want   bool
+	}{
+		{"exact", []input.State{
+			{Touched: true, Pad: padDown},
+			{Touched: true, Pad: padDownRight},
+			{Touched: true, Pad: padRight, Buttons: w},
+		}, true},
+		{"with noise", []input.State{
+			{Touched: true, Pad: padDown},
+			{Touched: true, Pad: padDown, Buttons: firefly.Buttons{E: true}},
+			{Touched: true, Pad: padDownRight},
+			{Touched: true, Pad: padRight},
+			{Touched: true, Pad: padRight},
+			{Touched: true, Pad: padRight, Buttons: w},
+		}, true},
+		{"wrong order", []input.State{
+			{Touched: true, Pad: padDownRight},
+			{Touched: true, Pad: padDown},
+			{Touched: true, Pad: padRight, Buttons: w},
+		}, false},
+		{"no button", []input.State{
+			{Touched: true, Pad: padDown},
+			{Touched: true, Pad: padDownRight},
+			{Touched: true, Pad: padRight},
+		}, false},
+		{"too slow", slices.Concat(
+			[]input.State{
+				{Touched: true, Pad: padDown},
+				{Touched: true, Pad: padDownRight},
+			},
+			slices.Repeat([]input.State{{Touched: true, Pad: padRight}}, 9),
+			[]input.State{{Touched: true, Pad: padRight, Buttons: w}},
+		), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			b := input.NewBuffer(input.DefaultBufferSize)
+			for _, s := range tc.states {
+				b.Push(s)
+			}
+			if got := b.Match(combo); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+			// The combo is reported only on the frame when it's completed.
+			b.Push(tc.states[len(tc.states)-1])
+			if b.Match(combo) {
+				t.Errorf("the combo must be reported only once")
+			}
+		})
+	}
+}
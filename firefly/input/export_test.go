@@ -0,0 +1,8 @@
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// Set the online peers without calling the host, as if [Manager.Update] was called.
+func (m *Manager) SetPeers(peers ...firefly.Peer) {
+	m.peers = peers
+}
@@ -0,0 +1,296 @@
+package input
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// The kind of a touch pad [Gesture].
+type GestureKind uint8
+
+const (
+	// A short touch without moving the finger.
+	Tap GestureKind = iota + 1
+	// The second tap shortly after the first one.
+	//
+	// The first tap is still reported as [Tap] when it happens.
+	DoubleTap
+	// A long touch without moving the finger.
+	//
+	// Reported while the pad is still touched, as soon as the touch is long enough.
+	LongPress
+	// Moving the finger across the pad and releasing it.
+	Swipe
+	// A swipe with the finger still moving fast when released.
+	Flick
+	// Moving the finger in a circle around the pad center.
+	//
+	// Reported every time the finger makes [GestureConfig.SpinMinAngle],
+	// so spinning continuously produces a spin every turn.
+	Spin
+)
+
+// A recognized touch pad gesture.
+type Gesture struct {
+	Kind GestureKind
+
+	// Where the touch started.
+	Start firefly.Pad
+
+	// Where the finger was when the gesture was recognized.
+	End firefly.Pad
+
+	// The direction of a [Swipe] or [Flick].
+	Direction firefly.DPad4
+
+	// The speed of a [Swipe] or [Flick] in pad units per frame.
+	Velocity int
+
+	// The angle of a [Spin]. Positive is counter-clockwise.
+	Angle firefly.Angle
+
+	// How many frames the touch lasted so far.
+	Frames int
+}
+
+// Thresholds for recognizing gestures.
+//
+// Distances are in pad units (the pad is 2000 units wide), durations are in frames.
+type GestureConfig struct {
+	// The longest touch that is still a tap.
+	TapMaxFrames int
+
+	// How far the finger can move during a tap or a long press.
+	TapMaxDistance int
+
+	// The longest pause between two taps of a double tap.
+	DoubleTapFrames int
+
+	// The shortest touch that is a long press.
+	LongPressFrames int
+
+	// The shortest distance between the start and the end of a swipe.
+	SwipeMinDistance int
+
+	// The lowest velocity of the finger at the moment of release for a flick.
+	//
+	// Slower swipes are reported as [Swipe].
+	FlickMinVelocity int
+
+	// The number of the last frames of a touch used to calculate the flick velocity.
+	FlickFrames int
+
+	// The angle the finger must make to produce a spin.
+	SpinMinAngle firefly.Angle
+
+	// The shortest distance from the pad center at which the movement counts as spinning.
+	SpinMinRadius int
+}
+
+// Thresholds that feel natural on the device at 60 FPS.
+func DefaultGestureConfig() GestureConfig {
+	return GestureConfig{
+		TapMaxFrames:     15,
+		TapMaxDistance:   200,
+		DoubleTapFrames:  20,
+		LongPressFrames:  40,
+		SwipeMinDistance: 600,
+		FlickMinVelocity: 150,
+		FlickFrames:      3,
+		SpinMinAngle:     firefly.Degrees(360),
+		SpinMinRadius:    500,
+	}
+}
+
+// Recognizes gestures from touch pad states of a single peer.
+//
+// Usually, you don't need to create it, [Tracker.Gestures] uses one internally.
+//
+// Constructed by [NewRecognizer].
+type Recognizer struct {
+	Config GestureConfig
+
+	frame     int
+	touching  bool
+	start     firefly.Pad
+	startAt   int
+	frames    int
+	moved     int
+	recent    []firefly.Pad
+	longFired bool
+	spinning  bool
+	spun      bool
+	spin      float32
+	azimuth   float32
+	lastTapAt int
+	tapped    bool
+}
+
+// Create a recognizer with the given thresholds.
+func NewRecognizer(c GestureConfig) *Recognizer {
+	return &Recognizer{Config: c}
+}
+
+// Process the state on a new frame and return the gestures recognized on this frame.
+func (r *Recognizer) Update(s State) []Gesture {
+	r.frame++
+	var result []Gesture
+	switch {
+	case s.Touched && !r.touching:
+		r.begin(s.Pad)
+		// Remember the start angle for spins.
+		r.checkSpin(s.Pad)
+	case s.Touched:
+		r.move(s.Pad)
+		if g, ok := r.checkLongPress(); ok {
+			result = append(result, g)
+		}
+		if g, ok := r.checkSpin(s.Pad); ok {
+			result = append(result, g)
+		}
+	case r.touching:
+		if g, ok := r.end(); ok {
+			result = append(result, g)
+		}
+	}
+	r.touching = s.Touched
+	return result
+}
+
+func (r *Recognizer) begin(p firefly.Pad) {
+	r.start = p
+	r.startAt = r.frame
+	r.frames = 1
+	r.moved = 0
+	r.recent = append(r.recent[:0], p)
+	r.longFired = false
+	r.spinning = false
+	r.spun = false
+	r.spin = 0
+}
+
+func (r *Recognizer) move(p firefly.Pad) {
+	r.frames++
+	r.moved = max(r.moved, distance(r.start, p))
+	r.recent = append(r.recent, p)
+	if keep := max(r.Config.FlickFrames, 1) + 1; len(r.recent) > keep {
+		r.recent = r.recent[len(r.recent)-keep:]
+	}
+}
+
+func (r *Recognizer) last() firefly.Pad {
+	return r.recent[len(r.recent)-1]
+}
+
+func (r *Recognizer) gesture(kind GestureKind) Gesture {
+	return Gesture{Kind: kind, Start: r.start, End: r.last(), Frames: r.frames}
+}
+
+func (r *Recognizer) checkLongPress() (Gesture, bool) {
+	if r.longFired || r.frames < r.Config.LongPressFrames || r.moved > r.Config.TapMaxDistance {
+		return Gesture{}, false
+	}
+	r.longFired = true
+	return r.gesture(LongPress), true
+}
+
+func (r *Recognizer) checkSpin(p firefly.Pad) (Gesture, bool) {
+	minRadius := r.Config.SpinMinRadius
+	if p.RadiusSquared() < minRadius*minRadius {
+		r.spinning = false
+		return Gesture{}, false
+	}
+	azimuth := p.Azimuth().Degrees()
+	if !r.spinning {
+		r.spinning = true
+		r.azimuth = azimuth
+		return Gesture{}, false
+	}
+	delta := azimuth - r.azimuth
+	// Take the shortest way around the circle.
+	for delta > 180 {
+		delta -= 360
+	}
+	for delta < -180 {
+		delta += 360
+	}
+	r.azimuth = azimuth
+	r.spin += delta
+	if abs32(r.spin) < r.Config.SpinMinAngle.Degrees() {
+		return Gesture{}, false
+	}
+	g := r.gesture(Spin)
+	g.Angle = firefly.Degrees(r.spin)
+	r.spin = 0
+	r.spun = true
+	return g, true
+}
+
+func (r *Recognizer) end() (Gesture, bool) {
+	if r.longFired || r.spun {
+		return Gesture{}, false
+	}
+	c := r.Config
+	if r.frames <= c.TapMaxFrames && r.moved <= c.TapMaxDistance {
+		g := r.gesture(Tap)
+		if r.tapped && r.startAt-r.lastTapAt <= c.DoubleTapFrames {
+			g.Kind = DoubleTap
+			r.tapped = false
+		} else {
+			r.tapped = true
+			r.lastTapAt = r.frame
+		}
+		return g, true
+	}
+	end := r.last()
+	dist := distance(r.start, end)
+	first := r.recent[0]
+	flickVelocity := distance(first, end) / max(len(r.recent)-1, 1)
+	switch {
+	case flickVelocity >= c.FlickMinVelocity:
+		g := r.gesture(Flick)
+		g.Direction = direction(first, end)
+		g.Velocity = flickVelocity
+		return g, true
+	case dist >= c.SwipeMinDistance:
+		g := r.gesture(Swipe)
+		g.Direction = direction(r.start, end)
+		g.Velocity = dist / r.frames
+		return g, true
+	}
+	return Gesture{}, false
+}
+
+func distance(a, b firefly.Pad) int {
+	return int(firefly.Pad{X: b.X - a.X, Y: b.Y - a.Y}.Radius())
+}
+
+// The main direction of the movement from a to b.
+func direction(a, b firefly.Pad) firefly.DPad4 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	switch {
+	case dx == 0 && dy == 0:
+		return firefly.DPad4None
+	case abs(dx) >= abs(dy) && dx > 0:
+		return firefly.DPad4Right
+	case abs(dx) >= abs(dy):
+		return firefly.DPad4Left
+	case dy > 0:
+		return firefly.DPad4Up
+	default:
+		return firefly.DPad4Down
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func abs32(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
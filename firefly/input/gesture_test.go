@@ -0,0 +1,129 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/input"
+)
+
+// Feed the touch positions into the recognizer, one per frame,
+// and then release the pad. Returns all recognized gestures.
+func feed(r *input.Recognizer, pads ...firefly.Pad) []input.Gesture {
+	var result []input.Gesture
+	for _, p := range pads {
+		result = append(result, r.Update(input.State{Pad: p, Touched: true})...)
+	}
+	result = append(result, r.Update(input.State{})...)
+	return result
+}
+
+func kinds(gestures []input.Gesture) []input.GestureKind {
+	result := make([]input.GestureKind, 0, len(gestures))
+	for _, g := range gestures {
+		result = append(result, g.Kind)
+	}
+	return result
+}
+
+func TestRecognizer_Tap(t *testing.T) {
+	t.Parallel()
+	r := input.NewRecognizer(input.DefaultGestureConfig())
+	p := firefly.Pad{X: 100, Y: 100}
+	got := feed(r, p, p, p)
+	if len(got) != 1 || got[0].Kind != input.Tap {
+		t.Fatalf("want a tap, got %v", kinds(got))
+	}
+	r.Update(input.State{})
+	got = feed(r, p, p)
+	if len(got) != 1 || got[0].Kind != input.DoubleTap {
+		t.Fatalf("want a double tap, got %v", kinds(got))
+	}
+	// The third tap starts a new sequence.
+	got = feed(r, p)
+	if len(got) != 1 || got[0].Kind != input.Tap {
+		t.Fatalf("want a tap, got %v", kinds(got))
+	}
+}
+
+func TestRecognizer_LongPress(t *testing.T) {
+	t.Parallel()
+	config := input.DefaultGestureConfig()
+	config.LongPressFrames = 5
+	r := input.NewRecognizer(config)
+	pads := make([]firefly.Pad, 10)
+	got := feed(r, pads...)
+	if len(got) != 1 || got[0].Kind != input.LongPress || got[0].Frames != 5 {
+		t.Fatalf("want a single long press, got %+v", got)
+	}
+}
+
+// Check that the value is within 5% of the expected one.
+//
+// Outside of TinyGo, tinymath.Sqrt is a fast approximation.
+func nearInt(got, want int) bool {
+	return got*100 >= want*95 && got*100 <= want*105
+}
+
+func TestRecognizer_Swipe(t *testing.T) {
+	t.Parallel()
+	r := input.NewRecognizer(input.DefaultGestureConfig())
+	// Move fast to the left and then stop before releasing.
+	pads := []firefly.Pad{{X: 400}, {X: 200}, {X: 0}, {X: -200}, {X: -400}}
+	for range 5 {
+		pads = append(pads, firefly.Pad{X: -400})
+	}
+	got := feed(r, pads...)
+	if len(got) != 1 || got[0].Kind != input.Swipe || got[0].Direction != firefly.DPad4Left {
+		t.Fatalf("want a swipe to the left, got %+v", got)
+	}
+	if !nearInt(got[0].Velocity, 80) {
+		t.Errorf("want velocity 80, got %d", got[0].Velocity)
+	}
+
+	// The same movement without stopping is a flick.
+	got = feed(r, pads[:5]...)
+	if len(got) != 1 || got[0].Kind != input.Flick || got[0].Direction != firefly.DPad4Left {
+		t.Fatalf("want a flick to the left, got %+v", got)
+	}
+	if !nearInt(got[0].Velocity, 200) {
+		t.Errorf("want velocity 200, got %d", got[0].Velocity)
+	}
+}
+
+func TestRecognizer_Spin(t *testing.T) {
+	t.Parallel()
+	r := input.NewRecognizer(input.DefaultGestureConfig())
+	// A full circle counter-clockwise, starting on the right.
+	var pads []firefly.Pad
+	for range 2 {
+		pads = append(pads,
+			firefly.Pad{X: 800}, firefly.Pad{X: 600, Y: 600},
+			firefly.Pad{Y: 800}, firefly.Pad{X: -600, Y: 600},
+			firefly.Pad{X: -800}, firefly.Pad{X: -600, Y: -600},
+			firefly.Pad{Y: -800}, firefly.Pad{X: 600, Y: -600},
+		)
+	}
+	pads = append(pads, firefly.Pad{X: 800})
+	got := feed(r, pads...)
+	if len(got) != 2 || got[0].Kind != input.Spin || got[1].Kind != input.Spin {
+		t.Fatalf("want two spins, got %v", kinds(got))
+	}
+	if got[0].Angle.Degrees() < 359 {
+		t.Errorf("want a counter-clockwise spin, got %f degrees", got[0].Angle.Degrees())
+	}
+}
+
+func TestTracker_Gesture(t *testing.T) {
+	t.Parallel()
+	tr := input.NewTracker(input.DefaultHistory)
+	tr.Push(input.State{Touched: true})
+	tr.Push(input.State{})
+	if _, ok := tr.Gesture(input.Tap); !ok {
+		t.Errorf("want a tap, got %v", kinds(tr.Gestures()))
+	}
+	tr.Push(input.State{})
+	if len(tr.Gestures()) != 0 {
+		t.Errorf("gestures must be reported only once")
+	}
+}
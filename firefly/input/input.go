@@ -0,0 +1,153 @@
+// Input manager that tracks the state of all peers between frames.
+//
+// The functions like [firefly.Buttons.JustPressed] need the state
+// from the previous frame, which means every game has to store it for every peer.
+// The [Manager] does this bookkeeping: call [Manager.Update] once per update
+// and then ask it what was just pressed, for how long a button is held, and so on.
+// It also recognizes touch pad gestures, see [Tracker.Gestures],
+// and buffers inputs for combos, see [Tracker.Buffer].
+// For treating the touch pad as an analog stick, see [Analog].
+// For merging the input of all peers in co-op games, see [Manager.Aggregated].
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// How many frames of history are kept for each peer by default.
+const DefaultHistory = 60
+
+// The input state of a peer on a single frame.
+type State struct {
+	// The touch pad position. Zero if the pad isn't touched.
+	Pad firefly.Pad
+
+	// True if the touch pad is touched.
+	Touched bool
+
+	// The pressed buttons.
+	Buttons firefly.Buttons
+}
+
+// Read the current input state of the peer.
+//
+// The peer can be [firefly.Combined] or one of the [firefly.GetPeers].
+func Read(p firefly.Peer) State {
+	pad, touched := firefly.ReadPad(p)
+	return State{Pad: pad, Touched: touched, Buttons: firefly.ReadButtons(p)}
+}
+
+// Tracks the input of all peers.
+//
+// Constructed by [New].
+type Manager struct {
+	// How many frames of history to keep for each peer.
+	//
+	// Changing it affects only peers that haven't been seen yet.
+	History int
+
+	// Thresholds for recognizing gestures.
+	//
+	// Changing it affects only peers that haven't been seen yet.
+	Gestures GestureConfig
+
+	// Timings for auto-repeating held inputs.
+	//
+	// Changing it affects only peers that haven't been seen yet.
+	Repeat RepeatConfig
+
+	// How to merge the input of all peers for [Manager.Aggregated].
+	Aggregation Aggregation
+
+	trackers   map[firefly.Peer]*Tracker
+	aggregated *Tracker
+	peers      []firefly.Peer
+	frame      int
+}
+
+// Create a new input manager.
+func New() *Manager {
+	return &Manager{
+		History:  DefaultHistory,
+		Gestures: DefaultGestureConfig(),
+		Repeat:   DefaultRepeatConfig(),
+		trackers: make(map[firefly.Peer]*Tracker),
+	}
+}
+
+// Read the input of all online peers and of [firefly.Combined].
+//
+// Must be called exactly once on every update, before checking the input.
+// Peers that went offline are forgotten.
+func (m *Manager) Update() {
+	online := firefly.GetPeers()
+	for _, peer := range m.peers {
+		if !online.Contains(peer) {
+			delete(m.trackers, peer)
+		}
+	}
+	m.peers = online.Slice()
+	for _, peer := range m.peers {
+		m.Get(peer).Push(Read(peer))
+	}
+	m.Combined().Push(Read(firefly.Combined))
+	m.Aggregated().Push(m.Aggregation.Apply(m.States()))
+	m.frame++
+}
+
+// The number of calls to [Manager.Update].
+func (m *Manager) Frame() int {
+	return m.frame
+}
+
+// The peers online during the last [Manager.Update].
+func (m *Manager) Peers() []firefly.Peer {
+	return m.peers
+}
+
+// The current states of all online peers.
+func (m *Manager) States() []State {
+	states := make([]State, 0, len(m.peers))
+	for _, peer := range m.peers {
+		states = append(states, m.Get(peer).Current())
+	}
+	return states
+}
+
+// The input of all peers merged using [Manager.Aggregation].
+//
+// An alternative to [Manager.Combined] for co-op games,
+// for example, when all peers must press a button together.
+// The tracker is created on the first call, like the trackers of peers.
+func (m *Manager) Aggregated() *Tracker {
+	if m.aggregated == nil {
+		m.aggregated = m.newTracker()
+	}
+	return m.aggregated
+}
+
+// The input of the given peer.
+//
+// The tracker for a peer that hasn't been seen yet is created on the first call.
+// Use [Tracker.Push] on it to feed the input manually, for example, in tests or replays.
+func (m *Manager) Get(p firefly.Peer) *Tracker {
+	t, found := m.trackers[p]
+	if !found {
+		t = m.newTracker()
+		m.trackers[p] = t
+	}
+	return t
+}
+
+// Create a tracker using the manager configuration.
+func (m *Manager) newTracker() *Tracker {
+	t := NewTracker(m.History)
+	t.recognizer.Config = m.Gestures
+	t.repeater.Config = m.Repeat
+	return t
+}
+
+// The combined input of all peers.
+//
+// Useful for single-player games, see [firefly.Combined].
+func (m *Manager) Combined() *Tracker {
+	return m.Get(firefly.Combined)
+}
@@ -0,0 +1,89 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/input"
+)
+
+func TestTracker_Buttons(t *testing.T) {
+	t.Parallel()
+	tr := input.NewTracker(3)
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	if !tr.JustPressed().S || tr.Held().S {
+		t.Errorf("S must be just pressed")
+	}
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true, E: true}})
+	tr.Push(input.State{Buttons: firefly.Buttons{S: true}})
+	if !tr.Held().S || tr.JustPressed().S {
+		t.Errorf("S must be held")
+	}
+	if !tr.JustReleased().E {
+		t.Errorf("E must be just released")
+	}
+	if tr.HeldFor(input.ButtonS) != 3 || tr.HeldFor(input.ButtonE) != 0 {
+		t.Errorf("wrong hold durations: %d and %d", tr.HeldFor(input.ButtonS), tr.HeldFor(input.ButtonE))
+	}
+	if !tr.Ago(1).Buttons.E || !tr.Ago(2).Buttons.S {
+		t.Errorf("wrong history")
+	}
+	tr.Push(input.State{})
+	// Only 3 frames are kept.
+	if tr.Ago(3) != (input.State{}) || !tr.Ago(2).Buttons.E {
+		t.Errorf("wrong history after overflow")
+	}
+}
+
+func TestTracker_Pad(t *testing.T) {
+	t.Parallel()
+	tr := input.NewTracker(input.DefaultHistory)
+	tr.Push(input.State{})
+	tr.Push(input.State{Touched: true, Pad: firefly.Pad{X: 900}})
+	if !tr.TouchStarted() || tr.TouchEnded() {
+		t.Errorf("the touch must be started")
+	}
+	if tr.DPad4JustPressed() != firefly.DPad4Right {
+		t.Errorf("right must be just pressed")
+	}
+	tr.Push(input.State{Touched: true, Pad: firefly.Pad{X: 900, Y: 900}})
+	got := tr.DPad8JustPressed()
+	if got != (firefly.DPad8{Up: true}) {
+		t.Errorf("only up must be just pressed, got %+v", got)
+	}
+	if tr.TouchedFor() != 2 {
+		t.Errorf("want 2 touched frames, got %d", tr.TouchedFor())
+	}
+	tr.Push(input.State{})
+	if !tr.TouchEnded() || tr.TouchedFor() != 0 {
+		t.Errorf("the touch must be ended")
+	}
+	if tr.DPad8JustReleased() != (firefly.DPad8{Up: true, Right: true}) {
+		t.Errorf("all directions must be released")
+	}
+}
+
+func TestManager_Get(t *testing.T) {
+	t.Parallel()
+	m := input.New()
+	m.Get(firefly.Peer{}).Push(input.State{Buttons: firefly.Buttons{W: true}})
+	if !m.Get(firefly.Peer{}).JustPressed().W {
+		t.Errorf("the tracker must be reused for the same peer")
+	}
+	if m.Combined().Buttons().W {
+		t.Errorf("peers must be tracked separately")
+	}
+}
+
+func TestManager_Aggregated(t *testing.T) {
+	t.Parallel()
+	m := input.New()
+	m.Repeat.Delay = 3
+	agg := m.Aggregated()
+	if got := agg.Repeater().Config; got != m.Repeat {
+		t.Errorf("the aggregated tracker must use the manager config, got %+v", got)
+	}
+	if m.Aggregated() != agg {
+		t.Errorf("the aggregated tracker must be reused")
+	}
+}
@@ -0,0 +1,127 @@
+package input
+
+import (
+	"slices"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// Waits for the player to press a button or a pad direction and binds it to the action.
+//
+// Use it in the controls settings screen: show "press a button for jump"
+// and call [Rebinder.Update] on every update until it returns true.
+//
+// Constructed by [Actions.Rebind].
+type Rebinder struct {
+	actions *Actions
+	peer    firefly.Peer
+	action  Action
+
+	// If true, the new binding is added to the existing ones instead of replacing them.
+	Add bool
+
+	// Called with the new binding when it's captured.
+	OnDone func(b Binding)
+
+	done bool
+	// Set when all inputs are released, see [Rebinder.Update].
+	armed bool
+}
+
+// Start waiting for the input to bind to the action.
+//
+// The menu button is never captured.
+// If the captured input is bound to another action of the peer,
+// it's removed from that action, so that one input doesn't trigger two actions.
+func (a *Actions) Rebind(p firefly.Peer, action Action) *Rebinder {
+	return &Rebinder{actions: a, peer: p, action: action}
+}
+
+// The action being rebound.
+func (r *Rebinder) Action() Action {
+	return r.action
+}
+
+// Check if the binding is captured.
+func (r *Rebinder) Done() bool {
+	return r.done
+}
+
+// Check the input of the peer and bind the first pressed input.
+//
+// Inputs are captured only after all buttons and the pad are released once,
+// so the button that opened the rebinder isn't bound right away.
+//
+// Should be called on every update after [Manager.Update].
+// Returns true when the binding is captured.
+func (r *Rebinder) Update() bool {
+	if r.done {
+		return true
+	}
+	if !r.armed {
+		r.armed = r.released()
+		return false
+	}
+	binding, ok := r.capture()
+	if !ok {
+		return false
+	}
+	r.done = true
+	a := r.actions
+	// Remove the input from other actions.
+	actions := make(map[Action]bool)
+	for action := range a.Defaults {
+		actions[action] = true
+	}
+	for action := range a.Overrides(r.peer) {
+		actions[action] = true
+	}
+	for action := range actions {
+		if action == r.action {
+			continue
+		}
+		old := a.Bindings(r.peer, action)
+		if idx := slices.Index(old, binding); idx >= 0 {
+			a.Bind(r.peer, action, slices.Delete(slices.Clone(old), idx, idx+1)...)
+		}
+	}
+	bindings := []Binding{binding}
+	if r.Add {
+		bindings = slices.Clone(a.Bindings(r.peer, r.action))
+		if !slices.Contains(bindings, binding) {
+			bindings = append(bindings, binding)
+		}
+	}
+	a.Bind(r.peer, r.action, bindings...)
+	if r.OnDone != nil {
+		r.OnDone(binding)
+	}
+	return true
+}
+
+// Check if no buttons and no pad directions are pressed.
+func (r *Rebinder) released() bool {
+	t := r.actions.Input.Get(r.peer)
+	buttons := t.Buttons()
+	for b := range ButtonMenu {
+		if b.In(buttons) {
+			return false
+		}
+	}
+	return t.DPad4() == firefly.DPad4None
+}
+
+// The first input that was just pressed.
+func (r *Rebinder) capture() (Binding, bool) {
+	t := r.actions.Input.Get(r.peer)
+	pressed := t.JustPressed()
+	for b := range ButtonMenu {
+		if b.In(pressed) {
+			return BindButton(b), true
+		}
+	}
+	if d := t.DPad4JustPressed(); d != firefly.DPad4None {
+		return BindDPad(d), true
+	}
+	return Binding{}, false
+}
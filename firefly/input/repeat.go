@@ -0,0 +1,141 @@
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// Settings for [Repeater].
+//
+// All durations are in frames.
+type RepeatConfig struct {
+	// How long the input must be held before it starts repeating.
+	Delay int
+
+	// The time between the first two repeats.
+	Interval int
+
+	// By how much the interval decreases after each repeat.
+	//
+	// Makes scrolling through long lists faster the longer the input is held.
+	// Zero means the input always repeats with the same interval.
+	Acceleration int
+
+	// The shortest interval that the acceleration can reach.
+	MinInterval int
+}
+
+// The default settings for [Repeater].
+func DefaultRepeatConfig() RepeatConfig {
+	return RepeatConfig{
+		Delay:        20,
+		Interval:     6,
+		Acceleration: 1,
+		MinInterval:  2,
+	}
+}
+
+// Auto-repeat for held buttons and pad directions.
+//
+// When an input is pressed, it's reported once, then, if it's still held
+// after [RepeatConfig.Delay], it's reported again every [RepeatConfig.Interval].
+// That's how "press and hold to scroll" in menus works.
+//
+// Obtained from [Tracker.Repeater] or constructed by [NewRepeater].
+type Repeater struct {
+	Config RepeatConfig
+
+	buttons [buttonCount]repeat
+	dpad4   repeat
+	dpad8   [4]repeat
+
+	lastDPad4 firefly.DPad4
+
+	firedButtons firefly.Buttons
+	firedDPad4   firefly.DPad4
+	firedDPad8   firefly.DPad8
+}
+
+// Create a repeater with the given settings.
+func NewRepeater(c RepeatConfig) *Repeater {
+	return &Repeater{Config: c}
+}
+
+// Process the state for a new frame.
+//
+// Called by [Tracker.Push].
+func (r *Repeater) Update(s State) {
+	c := r.Config
+	var fired [buttonCount]bool
+	for b := range Button(buttonCount) {
+		fired[b] = r.buttons[b].update(b.In(s.Buttons), c)
+	}
+	r.firedButtons = firefly.Buttons{
+		S:    fired[ButtonS],
+		E:    fired[ButtonE],
+		W:    fired[ButtonW],
+		N:    fired[ButtonN],
+		Menu: fired[ButtonMenu],
+	}
+
+	// Changing the direction starts the delay from the beginning.
+	d4 := dpad4(s)
+	if d4 != r.lastDPad4 {
+		r.dpad4 = repeat{}
+		r.lastDPad4 = d4
+	}
+	r.firedDPad4 = firefly.DPad4None
+	if r.dpad4.update(d4 != firefly.DPad4None, c) {
+		r.firedDPad4 = d4
+	}
+
+	d8 := dpad8(s)
+	r.firedDPad8 = firefly.DPad8{
+		Left:  r.dpad8[0].update(d8.Left, c),
+		Right: r.dpad8[1].update(d8.Right, c),
+		Up:    r.dpad8[2].update(d8.Up, c),
+		Down:  r.dpad8[3].update(d8.Down, c),
+	}
+}
+
+// Buttons that were just pressed or are repeated on this frame.
+func (r *Repeater) Buttons() firefly.Buttons {
+	return r.firedButtons
+}
+
+// The [firefly.DPad4] direction that was just pressed or is repeated on this frame.
+func (r *Repeater) DPad4() firefly.DPad4 {
+	return r.firedDPad4
+}
+
+// The [firefly.DPad8] directions that were just pressed or are repeated on this frame.
+func (r *Repeater) DPad8() firefly.DPad8 {
+	return r.firedDPad8
+}
+
+// The repeat state of a single input.
+type repeat struct {
+	// For how many frames the input is held.
+	held int
+	// On which frame of holding the input fires next.
+	next int
+	// The current interval between repeats.
+	interval int
+}
+
+// Update the state and report if the input fires on this frame.
+func (r *repeat) update(pressed bool, c RepeatConfig) bool {
+	if !pressed {
+		*r = repeat{}
+		return false
+	}
+	r.held++
+	if r.held == 1 {
+		r.next = 1 + max(c.Delay, 1)
+		r.interval = max(c.Interval, 1)
+		return true
+	}
+	if r.held < r.next {
+		return false
+	}
+	r.next = r.held + r.interval
+	r.interval = max(r.interval-c.Acceleration, c.MinInterval, 1)
+	return true
+}
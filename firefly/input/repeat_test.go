@@ -0,0 +1,61 @@
+package input_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/input"
+)
+
+func TestRepeater_Buttons(t *testing.T) {
+	t.Parallel()
+	r := input.NewRepeater(input.RepeatConfig{
+		Delay:        3,
+		Interval:     3,
+		Acceleration: 1,
+		MinInterval:  1,
+	})
+	var fired []int
+	for frame := 1; frame <= 12; frame++ {
+		r.Update(input.State{Buttons: firefly.Buttons{S: true}})
+		if r.Buttons().S {
+			fired = append(fired, frame)
+		}
+	}
+	want := []int{1, 4, 7, 9, 10, 11, 12}
+	if !slices.Equal(fired, want) {
+		t.Errorf("want %v, got %v", want, fired)
+	}
+	r.Update(input.State{})
+	r.Update(input.State{Buttons: firefly.Buttons{S: true}})
+	if !r.Buttons().S {
+		t.Errorf("pressing the button again must fire immediately")
+	}
+}
+
+func TestTracker_DPad4Repeated(t *testing.T) {
+	t.Parallel()
+	m := input.New()
+	m.Repeat = input.RepeatConfig{Delay: 3, Interval: 3}
+	tr := m.Get(firefly.Combined)
+	var fired []firefly.DPad4
+	push := func(s input.State) {
+		tr.Push(s)
+		fired = append(fired, tr.DPad4Repeated())
+	}
+	for range 5 {
+		push(touch(900, 0))
+	}
+	push(touch(0, 900))
+	push(touch(0, 900))
+	none := firefly.DPad4None
+	right := firefly.DPad4Right
+	want := []firefly.DPad4{right, none, none, right, none, firefly.DPad4Up, none}
+	if !slices.Equal(fired, want) {
+		t.Errorf("want %v, got %v", want, fired)
+	}
+	if tr.DPad8Repeated().Up || !tr.DPad8().Up {
+		t.Errorf("up must be held but not repeated yet")
+	}
+}
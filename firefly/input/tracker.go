@@ -0,0 +1,227 @@
+package input
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The input history of a single peer.
+//
+// Obtained from [Manager.Get] or [Manager.Combined], or constructed by [NewTracker].
+type Tracker struct {
+	// The ring buffer of states, the newest state is at head.
+	history []State
+	head    int
+	count   int
+
+	// For how many frames each button is pressed.
+	pressedFor [buttonCount]int
+	// For how many frames the pad is touched.
+	touchedFor int
+
+	recognizer *Recognizer
+	gestures   []Gesture
+	buffer     *Buffer
+	repeater   *Repeater
+}
+
+// Create a tracker that keeps the given number of frames of history.
+//
+// At least 2 frames (the current and the previous one) are always kept.
+// Gestures are recognized using [DefaultGestureConfig]
+// and [DefaultBufferSize] input events are buffered.
+// Held inputs are repeated using [DefaultRepeatConfig].
+func NewTracker(history int) *Tracker {
+	return &Tracker{
+		history:    make([]State, max(history, 2)),
+		recognizer: NewRecognizer(DefaultGestureConfig()),
+		buffer:     NewBuffer(DefaultBufferSize),
+		repeater:   NewRepeater(DefaultRepeatConfig()),
+	}
+}
+
+// Add the state for a new frame.
+//
+// Called by [Manager.Update] for every peer.
+func (t *Tracker) Push(s State) {
+	t.head = (t.head + 1) % len(t.history)
+	t.history[t.head] = s
+	t.count = min(t.count+1, len(t.history))
+	for b := range Button(buttonCount) {
+		if b.In(s.Buttons) {
+			t.pressedFor[b]++
+		} else {
+			t.pressedFor[b] = 0
+		}
+	}
+	if s.Touched {
+		t.touchedFor++
+	} else {
+		t.touchedFor = 0
+	}
+	t.gestures = t.recognizer.Update(s)
+	t.buffer.Push(s)
+	t.repeater.Update(s)
+}
+
+// The gesture recognizer. Use it to change the gesture thresholds.
+func (t *Tracker) Recognizer() *Recognizer {
+	return t.recognizer
+}
+
+// The buffer of input events, used for input buffering and combos.
+func (t *Tracker) Buffer() *Buffer {
+	return t.buffer
+}
+
+// The auto-repeat of held inputs. Use it to change the repeat timings.
+func (t *Tracker) Repeater() *Repeater {
+	return t.repeater
+}
+
+// Buttons that were just pressed or are auto-repeated because they are held.
+//
+// Use it for scrolling through menus. See [Repeater].
+func (t *Tracker) Repeated() firefly.Buttons {
+	return t.repeater.Buttons()
+}
+
+// The [firefly.DPad4] direction that was just pressed or is auto-repeated.
+func (t *Tracker) DPad4Repeated() firefly.DPad4 {
+	return t.repeater.DPad4()
+}
+
+// The [firefly.DPad8] directions that were just pressed or are auto-repeated.
+func (t *Tracker) DPad8Repeated() firefly.DPad8 {
+	return t.repeater.DPad8()
+}
+
+// Gestures recognized on the current frame.
+func (t *Tracker) Gestures() []Gesture {
+	return t.gestures
+}
+
+// The gesture of the given kind recognized on the current frame.
+func (t *Tracker) Gesture(kind GestureKind) (Gesture, bool) {
+	for _, g := range t.gestures {
+		if g.Kind == kind {
+			return g, true
+		}
+	}
+	return Gesture{}, false
+}
+
+// The state the given number of frames ago.
+//
+// Zero is the current frame, one is the previous frame, and so on.
+// Frames older than the kept history are empty.
+func (t *Tracker) Ago(frames int) State {
+	if frames < 0 || frames >= t.count {
+		return State{}
+	}
+	i := (t.head - frames + len(t.history)) % len(t.history)
+	return t.history[i]
+}
+
+// The state on the current frame.
+func (t *Tracker) Current() State {
+	return t.Ago(0)
+}
+
+// The state on the previous frame.
+func (t *Tracker) Previous() State {
+	return t.Ago(1)
+}
+
+// The currently pressed buttons.
+func (t *Tracker) Buttons() firefly.Buttons {
+	return t.Current().Buttons
+}
+
+// The current touch pad state.
+func (t *Tracker) Pad() (firefly.Pad, bool) {
+	s := t.Current()
+	return s.Pad, s.Touched
+}
+
+// Buttons that were not pressed on the previous frame but are pressed now.
+func (t *Tracker) JustPressed() firefly.Buttons {
+	return t.Buttons().JustPressed(t.Previous().Buttons)
+}
+
+// Buttons that were pressed on the previous frame but aren't pressed now.
+func (t *Tracker) JustReleased() firefly.Buttons {
+	return t.Buttons().JustReleased(t.Previous().Buttons)
+}
+
+// Buttons that were pressed on the previous frame and are still pressed now.
+func (t *Tracker) Held() firefly.Buttons {
+	return t.Buttons().Held(t.Previous().Buttons)
+}
+
+// For how many frames the button is pressed, including the current one.
+//
+// Zero if the button isn't pressed.
+func (t *Tracker) HeldFor(b Button) int {
+	if b >= buttonCount {
+		return 0
+	}
+	return t.pressedFor[b]
+}
+
+// For how many frames the pad is touched, including the current one.
+//
+// Zero if the pad isn't touched.
+func (t *Tracker) TouchedFor() int {
+	return t.touchedFor
+}
+
+// Check if the pad wasn't touched on the previous frame but is touched now.
+func (t *Tracker) TouchStarted() bool {
+	return t.Current().Touched && !t.Previous().Touched
+}
+
+// Check if the pad was touched on the previous frame but isn't touched now.
+func (t *Tracker) TouchEnded() bool {
+	return !t.Current().Touched && t.Previous().Touched
+}
+
+// The current pad state as [firefly.DPad4].
+//
+// If the pad isn't touched, returns [firefly.DPad4None].
+func (t *Tracker) DPad4() firefly.DPad4 {
+	return dpad4(t.Current())
+}
+
+// The direction of [firefly.DPad4] that wasn't pressed on the previous frame.
+func (t *Tracker) DPad4JustPressed() firefly.DPad4 {
+	return t.DPad4().JustPressed(dpad4(t.Previous()))
+}
+
+// The current pad state as [firefly.DPad8].
+//
+// If the pad isn't touched, no directions are pressed.
+func (t *Tracker) DPad8() firefly.DPad8 {
+	return dpad8(t.Current())
+}
+
+// Directions of [firefly.DPad8] that weren't pressed on the previous frame.
+func (t *Tracker) DPad8JustPressed() firefly.DPad8 {
+	return t.DPad8().JustPressed(dpad8(t.Previous()))
+}
+
+// Directions of [firefly.DPad8] that were pressed on the previous frame but aren't now.
+func (t *Tracker) DPad8JustReleased() firefly.DPad8 {
+	return t.DPad8().JustReleased(dpad8(t.Previous()))
+}
+
+func dpad4(s State) firefly.DPad4 {
+	if !s.Touched {
+		return firefly.DPad4None
+	}
+	return s.Pad.DPad4()
+}
+
+func dpad8(s State) firefly.DPad8 {
+	if !s.Touched {
+		return firefly.DPad8{}
+	}
+	return s.Pad.DPad8()
+}
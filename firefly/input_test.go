@@ -0,0 +1,32 @@
+package firefly_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+func TestButtons_JustPressed(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		old  firefly.Buttons
+		now  firefly.Buttons
+		want firefly.Buttons
+	}{
+		{"nothing", firefly.Buttons{}, firefly.Buttons{}, firefly.Buttons{}},
+		{"pressed", firefly.Buttons{}, firefly.Buttons{S: true}, firefly.Buttons{S: true}},
+		{"held", firefly.Buttons{S: true}, firefly.Buttons{S: true}, firefly.Buttons{}},
+		{"menu pressed", firefly.Buttons{N: true}, firefly.Buttons{N: true, Menu: true}, firefly.Buttons{Menu: true}},
+		{"menu held", firefly.Buttons{Menu: true}, firefly.Buttons{Menu: true}, firefly.Buttons{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := test.now.JustPressed(test.old)
+			if got != test.want {
+				t.Errorf("want %+v, but got %+v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package mask
+
+import (
+	"math/bits"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// Check if the masks placed at the given points have a common solid pixel.
+func Collide(a *Mask, pa firefly.Point, b *Mask, pb firefly.Point) bool {
+	return a.Overlaps(b, pb.Sub(pa))
+}
+
+// Check if the mask has a common solid pixel with the other mask
+// placed at the given offset relative to this one.
+func (m *Mask) Overlaps(other *Mask, offset firefly.Point) bool {
+	found := false
+	m.overlap(other, offset, func(w uint64) bool {
+		found = w != 0
+		return found
+	})
+	return found
+}
+
+// The number of common solid pixels of the mask and the other mask
+// placed at the given offset relative to this one.
+//
+// Can be used to find out how deep the objects are inside of each other.
+func (m *Mask) Overlap(other *Mask, offset firefly.Point) int {
+	count := 0
+	m.overlap(other, offset, func(w uint64) bool {
+		count += bits.OnesCount64(w)
+		return false
+	})
+	return count
+}
+
+// Call the function for every 64 pixels of the intersection
+// until the function returns true.
+func (m *Mask) overlap(other *Mask, offset firefly.Point, f func(uint64) bool) {
+	if m.Empty() || other.Empty() {
+		return
+	}
+	// The intersection of the bounding boxes in the coordinates of this mask.
+	left := max(m.min.X, other.min.X+offset.X)
+	top := max(m.min.Y, other.min.Y+offset.Y)
+	right := min(m.max.X, other.max.X+offset.X)
+	bottom := min(m.max.Y, other.max.Y+offset.Y)
+	if left >= right || top >= bottom {
+		return
+	}
+	for y := top; y < bottom; y++ {
+		for x := left; x < right; x += 64 {
+			w := m.bitsAt(y, x) & other.bitsAt(y-offset.Y, x-offset.X)
+			if n := right - x; n < 64 {
+				w &= 1<<n - 1
+			}
+			if f(w) {
+				return
+			}
+		}
+	}
+}
+
+// The smallest part of the image containing all non-transparent pixels.
+//
+// Also returns the position of the trimmed part relative to the original image,
+// so that the trimmed sprite can be drawn at the same place as the original one.
+// If the image is fully transparent, the size of the result is zero.
+func Trim(img firefly.SubImage) (firefly.SubImage, firefly.Point) {
+	point, size := OpaqueBounds(img)
+	trimmed := img.Image().Sub(img.Point().Add(point), size)
+	return trimmed, point
+}
+
+// The smallest rectangle containing all non-transparent pixels of the image.
+//
+// The point is relative to the top-left corner of the image.
+// If the image is fully transparent, the size is zero.
+func OpaqueBounds(img firefly.SubImage) (firefly.Point, firefly.Size) {
+	return FromSubImage(img).Bounds()
+}
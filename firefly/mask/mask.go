@@ -0,0 +1,163 @@
+// Pixel-perfect collision masks.
+//
+// A [Mask] is a bitmap of opaque pixels built from an image once,
+// for example when the game starts. Checking if two masks overlap
+// is fast: the masks are compared 64 pixels at a time,
+// and only in the area where their opaque bounding boxes intersect.
+package mask
+
+import (
+	"math/bits"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// A bitmap of solid pixels.
+//
+// Constructed by [New], [FromImage], or [FromSubImage].
+type Mask struct {
+	size firefly.Size
+	// Bits of all rows. Every row takes the same number of words,
+	// the lowest bit of the first word of the row is the leftmost pixel.
+	words []uint64
+	// Words per row.
+	stride int
+	// The opaque bounding box. Empty if the mask has no solid pixels.
+	min firefly.Point
+	max firefly.Point
+}
+
+// Create an empty mask of the given size.
+func New(s firefly.Size) *Mask {
+	s = firefly.S(max(s.W, 0), max(s.H, 0))
+	stride := (s.W + 63) / 64
+	return &Mask{
+		size:   s,
+		words:  make([]uint64, stride*s.H),
+		stride: stride,
+		min:    firefly.P(s.W, s.H),
+	}
+}
+
+// Create a mask from the image.
+//
+// Pixels of the [firefly.Image.Transparency] color are transparent,
+// all other pixels are solid.
+func FromImage(img firefly.Image) *Mask {
+	return FromSubImage(img.Sub(firefly.P(0, 0), img.Size()))
+}
+
+// Create a mask from the part of an image.
+//
+// Pixels of the [firefly.Image.Transparency] color are transparent,
+// all other pixels are solid.
+func FromSubImage(img firefly.SubImage) *Mask {
+	m := New(img.Size())
+	transp := img.Image().Transparency()
+	for y := range m.size.H {
+		for x := range m.size.W {
+			p := firefly.P(x, y)
+			c := img.GetPixel(p)
+			if c != transp && c != firefly.ColorNone {
+				m.Set(p, true)
+			}
+		}
+	}
+	return m
+}
+
+// The size of the mask, the same as the size of the image it was created from.
+func (m *Mask) Size() firefly.Size {
+	return m.size
+}
+
+// Check if the pixel is solid.
+//
+// Pixels outside of the mask are transparent.
+func (m *Mask) Get(p firefly.Point) bool {
+	if p.X < 0 || p.Y < 0 || p.X >= m.size.W || p.Y >= m.size.H {
+		return false
+	}
+	return m.words[p.Y*m.stride+p.X/64]>>(p.X%64)&1 == 1
+}
+
+// Make the pixel solid or transparent.
+//
+// Points outside of the mask are ignored.
+func (m *Mask) Set(p firefly.Point, solid bool) {
+	if p.X < 0 || p.Y < 0 || p.X >= m.size.W || p.Y >= m.size.H {
+		return
+	}
+	i := p.Y*m.stride + p.X/64
+	bit := uint64(1) << (p.X % 64)
+	if !solid {
+		m.words[i] &^= bit
+		m.updateBounds()
+		return
+	}
+	m.words[i] |= bit
+	if m.Empty() {
+		m.min = p
+		m.max = p.Add(firefly.P(1, 1))
+		return
+	}
+	m.min = firefly.P(min(m.min.X, p.X), min(m.min.Y, p.Y))
+	m.max = firefly.P(max(m.max.X, p.X+1), max(m.max.Y, p.Y+1))
+}
+
+// Check if the mask has no solid pixels.
+func (m *Mask) Empty() bool {
+	return m.min.X >= m.max.X || m.min.Y >= m.max.Y
+}
+
+// The number of solid pixels.
+func (m *Mask) Count() int {
+	count := 0
+	for _, w := range m.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// The smallest rectangle containing all solid pixels.
+//
+// The size is zero if the mask is empty.
+func (m *Mask) Bounds() (firefly.Point, firefly.Size) {
+	if m.Empty() {
+		return firefly.P(0, 0), firefly.S(0, 0)
+	}
+	return m.min, firefly.S(m.max.X-m.min.X, m.max.Y-m.min.Y)
+}
+
+// Recalculate the bounding box from scratch.
+func (m *Mask) updateBounds() {
+	m.min = firefly.P(m.size.W, m.size.H)
+	m.max = firefly.P(0, 0)
+	for y := range m.size.H {
+		row := m.words[y*m.stride : (y+1)*m.stride]
+		for i, w := range row {
+			if w == 0 {
+				continue
+			}
+			left := i*64 + bits.TrailingZeros64(w)
+			right := i*64 + 64 - bits.LeadingZeros64(w)
+			m.min = firefly.P(min(m.min.X, left), min(m.min.Y, y))
+			m.max = firefly.P(max(m.max.X, right), max(m.max.Y, y+1))
+		}
+	}
+}
+
+// 64 pixels of the row starting at x. Pixels outside of the mask are zero.
+func (m *Mask) bitsAt(y, x int) uint64 {
+	row := m.words[y*m.stride : (y+1)*m.stride]
+	i := x >> 6
+	shift := uint(x & 63)
+	var result uint64
+	if i >= 0 && i < len(row) {
+		result = row[i] >> shift
+	}
+	if shift != 0 && i+1 >= 0 && i+1 < len(row) {
+		result |= row[i+1] << (64 - shift)
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package mask_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/mask"
+)
+
+// A 6x4 image with a 3x2 solid block at (2, 1) on a transparent background.
+func testImage() firefly.Image {
+	canvas := firefly.NewCanvas(firefly.S(6, 4))
+	canvas.Fill(firefly.ColorWhite)
+	for y := 1; y < 3; y++ {
+		for x := 2; x < 5; x++ {
+			canvas.SetPixel(firefly.P(x, y), firefly.ColorRed)
+		}
+	}
+	img := canvas.Image()
+	img.SetTransparency(firefly.ColorWhite)
+	return img
+}
+
+func TestFromImage(t *testing.T) {
+	t.Parallel()
+	m := mask.FromImage(testImage())
+	if m.Count() != 6 {
+		t.Errorf("want 6 solid pixels, got %d", m.Count())
+	}
+	point, size := m.Bounds()
+	if point != firefly.P(2, 1) || size != firefly.S(3, 2) {
+		t.Errorf("wrong bounds %v %v", point, size)
+	}
+	if !m.Get(firefly.P(4, 2)) || m.Get(firefly.P(5, 2)) || m.Get(firefly.P(-1, 0)) {
+		t.Errorf("wrong pixels")
+	}
+
+	img := testImage()
+	img.SetTransparency(firefly.ColorNone)
+	if mask.FromImage(img).Count() != 24 {
+		t.Errorf("all pixels must be solid without transparency")
+	}
+}
+
+func TestMask_Set(t *testing.T) {
+	t.Parallel()
+	m := mask.New(firefly.S(100, 3))
+	if !m.Empty() {
+		t.Fatalf("a new mask must be empty")
+	}
+	m.Set(firefly.P(70, 1), true)
+	m.Set(firefly.P(3, 2), true)
+	point, size := m.Bounds()
+	if point != firefly.P(3, 1) || size != firefly.S(68, 2) {
+		t.Errorf("wrong bounds %v %v", point, size)
+	}
+	m.Set(firefly.P(3, 2), false)
+	point, size = m.Bounds()
+	if point != firefly.P(70, 1) || size != firefly.S(1, 1) {
+		t.Errorf("wrong bounds after removing %v %v", point, size)
+	}
+}
+
+func TestMask_Overlaps(t *testing.T) {
+	t.Parallel()
+	a := mask.FromImage(testImage())
+	b := mask.FromImage(testImage())
+	// A single pixel far to the right, crossing the word boundary.
+	wide := mask.New(firefly.S(130, 1))
+	wide.Set(firefly.P(66, 0), true)
+	tests := []struct {
+		other  *mask.Mask
+		offset firefly.Point
+		want   int
+	}{
+		{b, firefly.P(0, 0), 6},
+		{b, firefly.P(2, 1), 1},
+		{b, firefly.P(-2, -1), 1},
+		{b, firefly.P(3, 0), 0},
+		{b, firefly.P(0, 2), 0},
+		{b, firefly.P(100, 100), 0},
+		{wide, firefly.P(-62, 2), 1},
+		{wide, firefly.P(-64, 2), 1},
+		{wide, firefly.P(-61, 2), 0},
+		{wide, firefly.P(-65, 2), 0},
+	}
+	for _, test := range tests {
+		got := a.Overlap(test.other, test.offset)
+		if got != test.want {
+			t.Errorf("offset %v: want %d common pixels, got %d", test.offset, test.want, got)
+		}
+		if a.Overlaps(test.other, test.offset) != (test.want > 0) {
+			t.Errorf("offset %v: Overlaps doesn't match Overlap", test.offset)
+		}
+	}
+	if !mask.Collide(a, firefly.P(10, 10), b, firefly.P(12, 11)) {
+		t.Errorf("the masks must collide")
+	}
+}
+
+func TestTrim(t *testing.T) {
+	t.Parallel()
+	img := testImage()
+	sub := img.Sub(firefly.P(1, 0), firefly.S(5, 4))
+	trimmed, offset := mask.Trim(sub)
+	if offset != firefly.P(1, 1) {
+		t.Errorf("wrong offset %v", offset)
+	}
+	if trimmed.Point() != firefly.P(2, 1) || trimmed.Size() != firefly.S(3, 2) {
+		t.Errorf("wrong trimmed image at %v of size %v", trimmed.Point(), trimmed.Size())
+	}
+}
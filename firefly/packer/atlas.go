@@ -0,0 +1,104 @@
+package packer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+var ErrInvalidIndex = errors.New("invalid atlas index")
+
+// A named rectangular region of the atlas image.
+type Region struct {
+	Name  string
+	Point firefly.Point
+	Size  firefly.Size
+}
+
+// The result of packing: a single image and the regions of all packed images.
+type Atlas struct {
+	Image   firefly.Image
+	Regions []Region
+}
+
+// Load the atlas from the image and the index file produced by [Atlas.Index].
+func Load(image firefly.Image, index []byte) (*Atlas, error) {
+	regions, err := ParseIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	return &Atlas{Image: image, Regions: regions}, nil
+}
+
+// Find the region with the given name.
+func (a *Atlas) Region(name string) (Region, bool) {
+	for _, r := range a.Regions {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
+
+// Get the packed image with the given name.
+func (a *Atlas) Sub(name string) (firefly.SubImage, bool) {
+	r, ok := a.Region(name)
+	if !ok {
+		return firefly.SubImage{}, false
+	}
+	return a.Image.Sub(r.Point, r.Size), true
+}
+
+// Serialize the list of regions.
+//
+// The index is a text file, one region per line:
+//
+//	rect player 0 0 16 24
+//
+// The same format is understood by [LoadMeta].
+func (a *Atlas) Index() []byte {
+	var b bytes.Buffer
+	for _, r := range a.Regions {
+		fmt.Fprintf(&b, "rect %s %d %d %d %d\n", r.Name, r.Point.X, r.Point.Y, r.Size.W, r.Size.H)
+	}
+	return b.Bytes()
+}
+
+// Parse the index produced by [Atlas.Index].
+//
+// Empty lines and lines starting with "#" are ignored.
+func ParseIndex(index []byte) ([]Region, error) {
+	var regions []Region
+	scanner := bufio.NewScanner(bytes.NewReader(index))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 6 || fields[0] != "rect" {
+			return nil, fmt.Errorf("%w: line %d", ErrInvalidIndex, line)
+		}
+		var nums [4]int
+		for i := range nums {
+			n, err := strconv.Atoi(fields[i+2])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidIndex, line, err)
+			}
+			nums[i] = n
+		}
+		regions = append(regions, Region{
+			Name:  fields[1],
+			Point: firefly.P(nums[0], nums[1]),
+			Size:  firefly.S(nums[2], nums[3]),
+		})
+	}
+	return regions, nil
+}
@@ -0,0 +1,141 @@
+package packer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+var ErrInvalidMeta = errors.New("invalid atlas metadata")
+
+// Load the metadata of the [firefly.Atlas] from the given file.
+//
+// It's a text file with one directive per line.
+// Empty lines and lines starting with "#" are ignored.
+//
+//	# The size of grid cells. Overrides the size passed into NewAtlas.
+//	size 16 16
+//	# The empty space around the grid.
+//	margin 1
+//	# The empty space between grid cells.
+//	spacing 2
+//	# A named grid cell: column, row, and optional pivot.
+//	cell player_idle_0 0 0 pivot 8 15
+//	# A named region of any size: x, y, width, height, and optional pivot.
+//	rect big_tree 64 0 32 48 pivot 16 47
+//
+// The index produced by [Atlas.Index] uses the same format.
+func LoadMeta(a *firefly.Atlas, path string) error {
+	file := firefly.LoadFile(path, nil)
+	if !file.Exists() {
+		return fmt.Errorf("%w: file %s not found", ErrInvalidMeta, path)
+	}
+	return ParseMeta(a, file.Bytes())
+}
+
+// Parse the metadata of the [firefly.Atlas]. See [LoadMeta] for the format.
+func ParseMeta(a *firefly.Atlas, meta []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(meta))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		err := parseMetaLine(a, strings.Fields(text))
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return nil
+}
+
+func parseMetaLine(a *firefly.Atlas, fields []string) error {
+	switch fields[0] {
+	case "size":
+		nums, err := metaInts(fields[1:], 2)
+		if err != nil {
+			return err
+		}
+		a.SetSpriteSize(firefly.S(nums[0], nums[1]))
+	case "margin":
+		nums, err := metaInts(fields[1:], 1)
+		if err != nil {
+			return err
+		}
+		a.SetMargin(nums[0])
+	case "spacing":
+		nums, err := metaInts(fields[1:], 1)
+		if err != nil {
+			return err
+		}
+		a.SetSpacing(nums[0])
+	case "cell":
+		if len(fields) < 4 {
+			return fmt.Errorf("%w: cell needs a name, a column, and a row", ErrInvalidMeta)
+		}
+		nums, err := metaInts(fields[2:4], 2)
+		if err != nil {
+			return err
+		}
+		pivot, err := metaPivot(fields[4:])
+		if err != nil {
+			return err
+		}
+		a.SetNamed(fields[1], a.Sprite(nums[1], nums[0]).WithPivot(pivot))
+	case "rect":
+		if len(fields) < 6 {
+			return fmt.Errorf("%w: rect needs a name, a position, and a size", ErrInvalidMeta)
+		}
+		nums, err := metaInts(fields[2:6], 4)
+		if err != nil {
+			return err
+		}
+		pivot, err := metaPivot(fields[6:])
+		if err != nil {
+			return err
+		}
+		region := a.Region(firefly.P(nums[0], nums[1]), firefly.S(nums[2], nums[3]))
+		a.SetNamed(fields[1], region.WithPivot(pivot))
+	default:
+		return fmt.Errorf("%w: unknown directive %q", ErrInvalidMeta, fields[0])
+	}
+	return nil
+}
+
+// Parse exactly n integers.
+func metaInts(fields []string, n int) ([]int, error) {
+	if len(fields) != n {
+		return nil, fmt.Errorf("%w: expected %d numbers, got %d", ErrInvalidMeta, n, len(fields))
+	}
+	nums := make([]int, n)
+	for i, field := range fields {
+		v, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad number %q", ErrInvalidMeta, field)
+		}
+		nums[i] = v
+	}
+	return nums, nil
+}
+
+// Parse the optional "pivot x y" suffix.
+func metaPivot(fields []string) (firefly.Point, error) {
+	if len(fields) == 0 {
+		return firefly.Point{}, nil
+	}
+	if fields[0] != "pivot" {
+		return firefly.Point{}, fmt.Errorf("%w: unexpected %q", ErrInvalidMeta, fields[0])
+	}
+	nums, err := metaInts(fields[1:], 2)
+	if err != nil {
+		return firefly.Point{}, err
+	}
+	return firefly.P(nums[0], nums[1]), nil
+}
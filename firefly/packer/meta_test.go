@@ -0,0 +1,46 @@
+package packer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/packer"
+)
+
+func TestParseMeta(t *testing.T) {
+	t.Parallel()
+	atlas := firefly.NewAtlas(8, 8)
+	meta := []byte(`
+# player animation
+size 16 16
+margin 1
+spacing 2
+cell player_idle_0 1 0 pivot 8 15
+rect tree 64 0 32 48 pivot 16 47
+`)
+	err := packer.ParseMeta(&atlas, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	player := atlas.Named("player_idle_0")
+	if player.Point() != firefly.P(19, 1) || player.Size() != firefly.S(16, 16) {
+		t.Errorf("player: got %v %v", player.Point(), player.Size())
+	}
+	if player.Pivot() != firefly.P(8, 15) {
+		t.Errorf("player pivot: got %v", player.Pivot())
+	}
+	tree := atlas.Named("tree")
+	if tree.Point() != firefly.P(64, 0) || tree.Size() != firefly.S(32, 48) {
+		t.Errorf("tree: got %v %v", tree.Point(), tree.Size())
+	}
+	if atlas.Named("unknown").Exists() {
+		t.Errorf("unknown sprite must not exist")
+	}
+
+	err = packer.ParseMeta(&atlas, []byte("cell broken 1\n"))
+	if !errors.Is(err, packer.ErrInvalidMeta) {
+		t.Errorf("want ErrInvalidMeta, but got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+// Pack many images of different sizes into a single atlas image.
+//
+// Artists can deliver sprites as separate images and the game
+// still draws all of them from a single image. The packing can be done
+// on the device at boot time or on the host at build time
+// using the [packer command].
+//
+//	p := packer.New()
+//	_ = p.Add("player", firefly.LoadImage("player", nil))
+//	_ = p.Add("enemy", firefly.LoadImage("enemy", nil))
+//	atlas, err := p.Pack()
+//	player, _ := atlas.Sub("player")
+//
+// [packer command]: https://pkg.go.dev/github.com/firefly-zero/firefly-go/cmd/packer
+package packer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+var (
+	ErrEmpty       = errors.New("no images to pack")
+	ErrDuplicate   = errors.New("duplicate image name")
+	ErrTooWide     = errors.New("image is wider than the atlas")
+	ErrInvalidName = errors.New("invalid image name")
+)
+
+// Collects images to be packed into an atlas.
+//
+// Constructed by [New].
+type Packer struct {
+	// Empty pixels between images.
+	//
+	// Useful when images are scaled or drawn with subpixel offsets
+	// to prevent the neighbor image bleeding in.
+	Padding int
+
+	// The width of the atlas.
+	//
+	// If zero, the width is chosen automatically to make the atlas close to a square.
+	Width int
+
+	// The transparency color of the atlas.
+	//
+	// Transparent pixels of all images are converted into this color.
+	// If [firefly.ColorNone], the transparency color of the first image
+	// that has one is used. Opaque pixels of this color become transparent as well,
+	// so pick a color that the images don't use.
+	Transparency firefly.Color
+
+	items []item
+}
+
+type item struct {
+	name  string
+	image firefly.Image
+}
+
+// Create a new empty [Packer].
+func New() *Packer {
+	return &Packer{}
+}
+
+// Add the image to be packed.
+//
+// The name must be unique, it's used to find the image in the [Atlas].
+// It must not be empty, contain whitespace, or start with "#",
+// so that it can be written into the [Atlas.Index].
+func (p *Packer) Add(name string, image firefly.Image) error {
+	if !validName(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	p.items = append(p.items, item{name: name, image: image})
+	return nil
+}
+
+// Check if the name can be written into the index as a single field.
+func validName(name string) bool {
+	if name == "" || name[0] == '#' {
+		return false
+	}
+	return !strings.ContainsFunc(name, unicode.IsSpace)
+}
+
+// The number of images added so far.
+func (p *Packer) Len() int {
+	return len(p.items)
+}
+
+// Pack all added images into a single image.
+func (p *Packer) Pack() (*Atlas, error) {
+	if len(p.items) == 0 {
+		return nil, ErrEmpty
+	}
+	seen := make(map[string]struct{}, len(p.items))
+	for _, it := range p.items {
+		if _, found := seen[it.name]; found {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicate, it.name)
+		}
+		seen[it.name] = struct{}{}
+	}
+	width := p.Width
+	if width <= 0 {
+		width = p.autoWidth()
+	}
+	regions, height, err := p.place(width)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := firefly.NewCanvas(firefly.S(width, height))
+	key := p.transparency()
+	if key != firefly.ColorNone {
+		canvas.Image().SetTransparency(key)
+		canvas.Fill(key)
+	}
+	for i, r := range regions {
+		copyImage(canvas, p.items[i].image, r.Point, key)
+	}
+	return &Atlas{Image: canvas.Image(), Regions: regions}, nil
+}
+
+// Choose the atlas width so that the atlas is roughly square.
+func (p *Packer) autoWidth() int {
+	area := 0
+	widest := 0
+	for _, it := range p.items {
+		s := it.image.Size()
+		area += (s.W + p.Padding) * (s.H + p.Padding)
+		widest = max(widest, s.W)
+	}
+	// Leave some space for the gaps that shelf packing always has.
+	side := int(tinymath.Ceil(tinymath.Sqrt(float32(area) * 1.2)))
+	width := max(side, widest)
+	// Keep the width even, so that every row starts at a byte boundary.
+	return width + width%2
+}
+
+// Place all images using the shelf algorithm.
+//
+// Images are sorted by height and placed left-to-right in rows (shelves).
+// When the next image doesn't fit into the current shelf, a new shelf is started.
+// Returns regions in the same order as the items.
+func (p *Packer) place(width int) ([]Region, int, error) {
+	order := make([]int, len(p.items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return p.items[b].image.Height() - p.items[a].image.Height()
+	})
+
+	regions := make([]Region, len(p.items))
+	x, y, shelf := 0, 0, 0
+	for _, i := range order {
+		it := p.items[i]
+		size := it.image.Size()
+		if size.W > width {
+			return nil, 0, fmt.Errorf("%w: %s", ErrTooWide, it.name)
+		}
+		if x > 0 && x+size.W > width {
+			y += shelf + p.Padding
+			x, shelf = 0, 0
+		}
+		regions[i] = Region{Name: it.name, Point: firefly.P(x, y), Size: size}
+		x += size.W + p.Padding
+		shelf = max(shelf, size.H)
+	}
+	return regions, y + shelf, nil
+}
+
+// The transparency color for the atlas.
+func (p *Packer) transparency() firefly.Color {
+	if p.Transparency != firefly.ColorNone {
+		return p.Transparency
+	}
+	for _, it := range p.items {
+		c := it.image.Transparency()
+		if c != firefly.ColorNone {
+			return c
+		}
+	}
+	return firefly.ColorNone
+}
+
+// Copy all pixels of the image on the canvas at the given position.
+func copyImage(canvas firefly.Canvas, image firefly.Image, at firefly.Point, key firefly.Color) {
+	size := image.Size()
+	transparent := image.Transparency()
+	for y := range size.H {
+		for x := range size.W {
+			c := image.GetPixel(firefly.P(x, y))
+			if c == transparent {
+				c = key
+			}
+			canvas.SetPixel(firefly.P(at.X+x, at.Y+y), c)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package packer_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/packer"
+)
+
+func newImage(s firefly.Size, c firefly.Color) firefly.Image {
+	canvas := firefly.NewCanvas(s)
+	canvas.Fill(c)
+	return canvas.Image()
+}
+
+func TestPacker_Pack(t *testing.T) {
+	t.Parallel()
+	S := firefly.S
+	p := packer.New()
+	p.Padding = 1
+	p.Add("a", newImage(S(8, 4), firefly.ColorRed))
+	p.Add("b", newImage(S(4, 10), firefly.ColorBlue))
+	p.Add("c", newImage(S(6, 6), firefly.ColorGreen))
+	atlas, err := p.Pack()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(atlas.Regions) != 3 {
+		t.Fatalf("want 3 regions, but got %d", len(atlas.Regions))
+	}
+	want := map[string]firefly.Color{"a": firefly.ColorRed, "b": firefly.ColorBlue, "c": firefly.ColorGreen}
+	for i, r := range atlas.Regions {
+		size := atlas.Image.Size()
+		if r.Point.X+r.Size.W > size.W || r.Point.Y+r.Size.H > size.H {
+			t.Errorf("%s: region %v is out of the image %v", r.Name, r, size)
+		}
+		for _, other := range atlas.Regions[i+1:] {
+			if overlap(r, other) {
+				t.Errorf("regions %s and %s overlap", r.Name, other.Name)
+			}
+		}
+		sub, _ := atlas.Sub(r.Name)
+		got := sub.Image().GetPixel(sub.Point().Add(firefly.P(r.Size.W-1, r.Size.H-1)))
+		if got != want[r.Name] {
+			t.Errorf("%s: want %s, but got %s", r.Name, want[r.Name], got)
+		}
+	}
+}
+
+func overlap(a, b packer.Region) bool {
+	return a.Point.X < b.Point.X+b.Size.W && b.Point.X < a.Point.X+a.Size.W &&
+		a.Point.Y < b.Point.Y+b.Size.H && b.Point.Y < a.Point.Y+a.Size.H
+}
+
+func TestPacker_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := packer.New().Pack()
+	if !errors.Is(err, packer.ErrEmpty) {
+		t.Errorf("want ErrEmpty, but got %v", err)
+	}
+
+	p := packer.New()
+	p.Add("a", newImage(firefly.S(2, 2), firefly.ColorRed))
+	p.Add("a", newImage(firefly.S(2, 2), firefly.ColorRed))
+	_, err = p.Pack()
+	if !errors.Is(err, packer.ErrDuplicate) {
+		t.Errorf("want ErrDuplicate, but got %v", err)
+	}
+
+	p = packer.New()
+	p.Width = 4
+	p.Add("a", newImage(firefly.S(6, 2), firefly.ColorRed))
+	_, err = p.Pack()
+	if !errors.Is(err, packer.ErrTooWide) {
+		t.Errorf("want ErrTooWide, but got %v", err)
+	}
+
+	p = packer.New()
+	for _, name := range []string{"", "big tree", "tab\tname", "#comment"} {
+		err = p.Add(name, newImage(firefly.S(2, 2), firefly.ColorRed))
+		if !errors.Is(err, packer.ErrInvalidName) {
+			t.Errorf("%q: want ErrInvalidName, but got %v", name, err)
+		}
+	}
+	if p.Len() != 0 {
+		t.Errorf("images with invalid names must not be added")
+	}
+}
+
+func TestParseIndex(t *testing.T) {
+	t.Parallel()
+	atlas := &packer.Atlas{Regions: []packer.Region{
+		{Name: "player", Point: firefly.P(0, 0), Size: firefly.S(16, 24)},
+		{Name: "coin", Point: firefly.P(16, 0), Size: firefly.S(8, 8)},
+	}}
+	got, err := packer.ParseIndex(atlas.Index())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(got, atlas.Regions) {
+		t.Errorf("want %v, but got %v", atlas.Regions, got)
+	}
+
+	_, err = packer.ParseIndex([]byte("rect player 0 0 16\n"))
+	if !errors.Is(err, packer.ErrInvalidIndex) {
+		t.Errorf("want ErrInvalidIndex, but got %v", err)
+	}
+}
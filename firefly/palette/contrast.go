@@ -0,0 +1,70 @@
+package palette
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// How much [Palette.HighContrast] increases the contrast.
+const highContrast = 1.5
+
+// Change the contrast of all colors in the palette.
+//
+// Values above 1 make dark colors darker and light colors lighter,
+// values between 0 and 1 make the palette more washed out.
+// The center is the average luminance of the palette,
+// so the palette keeps its overall brightness.
+func (p Palette) Contrast(k float32) Palette {
+	total := 0
+	for _, c := range p {
+		total += int(Luma(c))
+	}
+	mid := float32(total) / float32(len(p))
+	for i, c := range p {
+		p[i] = firefly.RGB{
+			R: stretch(c.R, mid, k),
+			G: stretch(c.G, mid, k),
+			B: stretch(c.B, mid, k),
+		}
+	}
+	return p
+}
+
+func stretch(v uint8, mid, k float32) uint8 {
+	res := mid + (float32(v)-mid)*k
+	return uint8(min(max(res, 0), 255) + 0.5)
+}
+
+// Make the palette high-contrast.
+//
+// The darkest color becomes pure black, the lightest becomes pure white,
+// and all other colors are pushed away from the middle gray.
+func (p Palette) HighContrast() Palette {
+	darkest, lightest := 0, 0
+	for i, c := range p {
+		if Luma(c) < Luma(p[darkest]) {
+			darkest = i
+		}
+		if Luma(c) > Luma(p[lightest]) {
+			lightest = i
+		}
+	}
+	p = p.Contrast(highContrast)
+	p[darkest] = firefly.RGB{}
+	p[lightest] = firefly.NewRGB(0xff, 0xff, 0xff)
+	return p
+}
+
+// Adjust the palette according to the player's settings.
+//
+// If [firefly.Settings.Contrast] is set, returns [Palette.HighContrast].
+// Otherwise, returns the palette unchanged.
+//
+// The runtime adjusts only black and white of the default palette,
+// custom palettes should always be passed through this function.
+//
+//	settings := firefly.GetSettings(firefly.GetMe())
+//	palette.Pico8.Adjust(settings).Apply()
+func (p Palette) Adjust(s firefly.Settings) Palette {
+	if s.Contrast {
+		return p.HighContrast()
+	}
+	return p
+}
@@ -0,0 +1,89 @@
+package palette
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// Palette cycling: rotating a range of colors to animate water, fire, lava, etc.
+//
+// The image is drawn once and then only the palette changes,
+// which is much cheaper than redrawing the image on every frame.
+//
+// The zero value is a valid cycle that does nothing.
+type Cycle struct {
+	// The first color of the range (inclusive).
+	First firefly.Color
+
+	// The last color of the range (inclusive).
+	Last firefly.Color
+
+	// How many frames to wait before shifting colors by one position.
+	//
+	// Zero or one means shifting on every frame.
+	Delay int
+
+	// If true, colors move from the last to the first.
+	Backward bool
+
+	frame int
+	shift int
+}
+
+// The number of colors in the cycle range.
+func (c *Cycle) Len() int {
+	first := max(c.First, firefly.ColorBlack)
+	last := min(c.Last, firefly.ColorDarkGray)
+	if last < first {
+		return 0
+	}
+	return int(last-first) + 1
+}
+
+// Advance the cycle by one frame.
+//
+// Returns true if the colors were shifted on this frame
+// and so the palette must be applied again.
+func (c *Cycle) Update() bool {
+	size := c.Len()
+	if size < 2 {
+		return false
+	}
+	c.frame++
+	if c.frame < c.Delay {
+		return false
+	}
+	c.frame = 0
+	c.shift = (c.shift + 1) % size
+	return true
+}
+
+// Reset the cycle to the initial state.
+func (c *Cycle) Reset() {
+	c.frame = 0
+	c.shift = 0
+}
+
+// Rotate the colors of the given palette according to the current cycle state.
+func (c *Cycle) Rotate(p Palette) Palette {
+	size := c.Len()
+	if size < 2 || c.shift == 0 {
+		return p
+	}
+	first := int(max(c.First, firefly.ColorBlack)) - 1
+	src := p
+	for i := range size {
+		var j int
+		if c.Backward {
+			j = (i + c.shift) % size
+		} else {
+			j = (i - c.shift + size) % size
+		}
+		p[first+i] = src[first+j]
+	}
+	return p
+}
+
+// Apply the cycled colors of the given palette to the runtime.
+//
+// Only colors in the cycle range are set.
+func (c *Cycle) Apply(p Palette) {
+	c.Rotate(p).ApplyRange(c.First, c.Last)
+}
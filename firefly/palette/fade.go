@@ -0,0 +1,79 @@
+package palette
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// A smooth transition from one palette to another over the given number of frames.
+//
+// Call [Fade.Update] on every update and [Fade.Apply] on every render
+// (or right after the update). Constructed by [NewFade].
+type Fade struct {
+	from   Palette
+	to     Palette
+	frames int
+	frame  int
+}
+
+// Create a transition (cross-fade) between two palettes.
+//
+// If frames is zero or negative, the fade is instantly finished.
+func NewFade(from, to Palette, frames int) *Fade {
+	return &Fade{from: from, to: to, frames: max(frames, 0)}
+}
+
+// Fade all colors of the palette into black.
+func FadeToBlack(from Palette, frames int) *Fade {
+	return NewFade(from, from.Tint(firefly.RGB{}, 1), frames)
+}
+
+// Fade all colors of the palette into white.
+func FadeToWhite(from Palette, frames int) *Fade {
+	white := firefly.NewRGB(0xff, 0xff, 0xff)
+	return NewFade(from, from.Tint(white, 1), frames)
+}
+
+// Advance the fade by one frame.
+//
+// Returns false if the fade is already finished.
+func (f *Fade) Update() bool {
+	if f.Done() {
+		return false
+	}
+	f.frame++
+	return true
+}
+
+// Check if the fade reached the target palette.
+func (f *Fade) Done() bool {
+	return f.frame >= f.frames
+}
+
+// The progress of the fade, from 0.0 to 1.0.
+func (f *Fade) Progress() float32 {
+	if f.Done() {
+		return 1
+	}
+	return float32(f.frame) / float32(f.frames)
+}
+
+// The palette for the current frame.
+func (f *Fade) Current() Palette {
+	return Mix(f.from, f.to, f.Progress())
+}
+
+// Set the palette for the current frame as the runtime palette.
+func (f *Fade) Apply() {
+	f.Current().Apply()
+}
+
+// Swap the source and the target palettes, keeping the visible palette the same.
+//
+// Useful to fade back in after a fade out, or if the player interrupted the fade.
+func (f *Fade) Reverse() {
+	f.from, f.to = f.to, f.from
+	f.frame = f.frames - f.frame
+}
+
+// Start the fade from the beginning.
+func (f *Fade) Reset() {
+	f.frame = 0
+}
@@ -0,0 +1,118 @@
+// Tools for working with the color palette.
+//
+// The runtime supports only 16 colors at a time but each of them
+// can be set to any RGB value at any moment. The package provides
+// popular palettes, smooth transitions between palettes (fades),
+// palette cycling (animating colors without redrawing anything),
+// and contrast adjustment for players who enabled high contrast in settings.
+//
+// All operations on [Palette] are pure: they don't modify the palette
+// and don't call the host. Call [Palette.Apply] to actually set the palette.
+package palette
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// All 16 colors of a palette.
+//
+// The color [firefly.ColorBlack] (1) is at index 0,
+// [firefly.ColorPurple] (2) is at index 1, and so on.
+type Palette [16]firefly.RGB
+
+// Set the palette as the current palette of the runtime.
+func (p Palette) Apply() {
+	firefly.SetPalette(p)
+}
+
+// Set only the given colors of the palette (from first to last inclusive).
+//
+// Useful for palette cycling when only a few colors change.
+func (p Palette) ApplyRange(first, last firefly.Color) {
+	for c := max(first, firefly.ColorBlack); c <= min(last, firefly.ColorDarkGray); c++ {
+		firefly.SetColor(c, p[c-1])
+	}
+}
+
+// Get the RGB value of the given color.
+//
+// For [firefly.ColorNone] and invalid colors, returns zero (black) RGB.
+func (p Palette) Get(c firefly.Color) firefly.RGB {
+	if c == firefly.ColorNone || c > firefly.ColorDarkGray {
+		return firefly.RGB{}
+	}
+	return p[c-1]
+}
+
+// Make a copy of the palette with the given color changed.
+func (p Palette) With(c firefly.Color, v firefly.RGB) Palette {
+	if c == firefly.ColorNone || c > firefly.ColorDarkGray {
+		return p
+	}
+	p[c-1] = v
+	return p
+}
+
+// Find the color in the palette closest to the given RGB value.
+func (p Palette) Nearest(v firefly.RGB) firefly.Color {
+	best := firefly.ColorBlack
+	bestDist := -1
+	for i, c := range p {
+		dr := int(c.R) - int(v.R)
+		dg := int(c.G) - int(v.G)
+		db := int(c.B) - int(v.B)
+		// Weighted by the sensitivity of the human eye to each component.
+		dist := 3*dr*dr + 4*dg*dg + 2*db*db
+		if bestDist < 0 || dist < bestDist {
+			best = firefly.Color(i + 1)
+			bestDist = dist
+		}
+	}
+	return best
+}
+
+// Mix two palettes.
+//
+// The ratio t is clamped to the 0.0-1.0 range.
+// If t is 0, returns a. If t is 1, returns b.
+func Mix(a, b Palette, t float32) Palette {
+	var res Palette
+	for i := range res {
+		res[i] = MixRGB(a[i], b[i], t)
+	}
+	return res
+}
+
+// Mix two RGB values.
+//
+// The ratio t is clamped to the 0.0-1.0 range.
+// If t is 0, returns a. If t is 1, returns b.
+func MixRGB(a, b firefly.RGB, t float32) firefly.RGB {
+	t = min(max(t, 0), 1)
+	return firefly.RGB{
+		R: mixChannel(a.R, b.R, t),
+		G: mixChannel(a.G, b.G, t),
+		B: mixChannel(a.B, b.B, t),
+	}
+}
+
+func mixChannel(a, b uint8, t float32) uint8 {
+	v := float32(a) + (float32(b)-float32(a))*t
+	return uint8(v + 0.5)
+}
+
+// Make all colors of the palette closer to the given color.
+//
+// Same as [Mix] where all colors of the second palette are the same.
+func (p Palette) Tint(v firefly.RGB, t float32) Palette {
+	for i, c := range p {
+		p[i] = MixRGB(c, v, t)
+	}
+	return p
+}
+
+// Relative luminance of the color in the 0-255 range.
+func Luma(v firefly.RGB) uint8 {
+	// ITU BT.601 coefficients multiplied by 1000.
+	return uint8((299*int(v.R) + 587*int(v.G) + 114*int(v.B)) / 1000)
+}
@@ -0,0 +1,107 @@
+package palette_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/palette"
+)
+
+func TestMixRGB(t *testing.T) {
+	t.Parallel()
+	a := firefly.NewRGB(0, 100, 255)
+	b := firefly.NewRGB(200, 0, 255)
+	tests := []struct {
+		name string
+		t    float32
+		want firefly.RGB
+	}{
+		{name: "start", t: 0, want: a},
+		{name: "end", t: 1, want: b},
+		{name: "middle", t: 0.5, want: firefly.NewRGB(100, 50, 255)},
+		{name: "clamp below", t: -1, want: a},
+		{name: "clamp above", t: 2, want: b},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := palette.MixRGB(a, b, test.t)
+			if got != test.want {
+				t.Errorf("want %v, but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFade(t *testing.T) {
+	t.Parallel()
+	fade := palette.FadeToBlack(palette.Sweetie16, 4)
+	if fade.Current() != palette.Sweetie16 {
+		t.Errorf("the fade must start from the source palette")
+	}
+	frames := 0
+	for fade.Update() {
+		frames++
+	}
+	if frames != 4 {
+		t.Errorf("want 4 frames, but got %d", frames)
+	}
+	if fade.Current() != (palette.Palette{}) {
+		t.Errorf("the fade must end with all black")
+	}
+	fade.Reverse()
+	if fade.Current() != (palette.Palette{}) {
+		t.Errorf("reverse must not change the current palette")
+	}
+}
+
+func TestCycle(t *testing.T) {
+	t.Parallel()
+	p := palette.Grayscale
+	cycle := palette.Cycle{First: firefly.ColorRed, Last: firefly.ColorYellow, Delay: 2}
+	if cycle.Update() {
+		t.Errorf("the first frame must be delayed")
+	}
+	if !cycle.Update() {
+		t.Errorf("the second frame must shift the colors")
+	}
+	got := cycle.Rotate(p)
+	want := p
+	want[2], want[3], want[4] = p[4], p[2], p[3]
+	if got != want {
+		t.Errorf("want %v, but got %v", want, got)
+	}
+	cycle.Backward = true
+	got = cycle.Rotate(p)
+	want[2], want[3], want[4] = p[3], p[4], p[2]
+	if got != want {
+		t.Errorf("backward: want %v, but got %v", want, got)
+	}
+}
+
+func TestHighContrast(t *testing.T) {
+	t.Parallel()
+	p := palette.Sweetie16.HighContrast()
+	if p.Get(firefly.ColorBlack) != (firefly.RGB{}) {
+		t.Errorf("the darkest color must be black, got %v", p.Get(firefly.ColorBlack))
+	}
+	if p.Get(firefly.ColorWhite) != firefly.NewRGB(0xff, 0xff, 0xff) {
+		t.Errorf("the lightest color must be white, got %v", p.Get(firefly.ColorWhite))
+	}
+	if palette.Sweetie16.Adjust(firefly.Settings{}) != palette.Sweetie16 {
+		t.Errorf("the palette must not change if contrast is not requested")
+	}
+}
+
+func TestDefaultRGB(t *testing.T) {
+	t.Parallel()
+	got := firefly.ColorRed.DefaultRGB()
+	want := firefly.NewRGB(0xb1, 0x3e, 0x53)
+	if got != want {
+		t.Errorf("want %v, but got %v", want, got)
+	}
+	if palette.Sweetie16.Nearest(firefly.NewRGB(0xff, 0, 0)) != firefly.ColorRed {
+		t.Errorf("nearest to pure red must be red")
+	}
+}
@@ -0,0 +1,71 @@
+package palette
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The default palette of the runtime.
+//
+// [SWEETIE-16] by GrafxKid.
+//
+// [SWEETIE-16]: https://lospec.com/palette-list/sweetie-16
+var Sweetie16 = func() Palette {
+	var p Palette
+	for i := range p {
+		p[i] = firefly.Color(i + 1).DefaultRGB()
+	}
+	return p
+}()
+
+// The palette of the [PICO-8] fantasy console.
+//
+// [PICO-8]: https://lospec.com/palette-list/pico-8
+var Pico8 = Palette{
+	hex(0x000000), hex(0x1d2b53), hex(0x7e2553), hex(0x008751),
+	hex(0xab5236), hex(0x5f574f), hex(0xc2c3c7), hex(0xfff1e8),
+	hex(0xff004d), hex(0xffa300), hex(0xffec27), hex(0x00e436),
+	hex(0x29adff), hex(0x83769c), hex(0xff77a8), hex(0xffccaa),
+}
+
+// [DawnBringer 16] palette by DawnBringer.
+//
+// [DawnBringer 16]: https://lospec.com/palette-list/dawnbringer-16
+var DB16 = Palette{
+	hex(0x140c1c), hex(0x442434), hex(0x30346d), hex(0x4e4a4e),
+	hex(0x854c30), hex(0x346524), hex(0xd04648), hex(0x757161),
+	hex(0x597dce), hex(0xd27d2c), hex(0x8595a1), hex(0x6daa2c),
+	hex(0xd2aa99), hex(0x6dc2ca), hex(0xdad45e), hex(0xdeeed6),
+}
+
+// [Endesga 16] palette by Endesga.
+//
+// [Endesga 16]: https://lospec.com/palette-list/endesga-16
+var Endesga16 = Palette{
+	hex(0xe4a672), hex(0xb86f50), hex(0x743f39), hex(0x3f2832),
+	hex(0x9e2835), hex(0xe53b44), hex(0xfb922b), hex(0xffe762),
+	hex(0x63c64d), hex(0x327345), hex(0x193d3f), hex(0x4f6781),
+	hex(0xafbfd2), hex(0xffffff), hex(0x2ce8f4), hex(0x0484d1),
+}
+
+// The full 16-color palette of IBM [CGA].
+//
+// [CGA]: https://en.wikipedia.org/wiki/Color_Graphics_Adapter
+var CGA = Palette{
+	hex(0x000000), hex(0x0000aa), hex(0x00aa00), hex(0x00aaaa),
+	hex(0xaa0000), hex(0xaa00aa), hex(0xaa5500), hex(0xaaaaaa),
+	hex(0x555555), hex(0x5555ff), hex(0x55ff55), hex(0x55ffff),
+	hex(0xff5555), hex(0xff55ff), hex(0xffff55), hex(0xffffff),
+}
+
+// 16 shades of gray, from black to white.
+var Grayscale = func() Palette {
+	var p Palette
+	for i := range p {
+		v := uint8(i * 0x11)
+		p[i] = firefly.NewRGB(v, v, v)
+	}
+	return p
+}()
+
+// Make RGB from a hex value like 0xff00aa.
+func hex(v uint32) firefly.RGB {
+	return firefly.NewRGB(uint8(v>>16), uint8(v>>8), uint8(v))
+}
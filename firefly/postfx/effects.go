@@ -0,0 +1,221 @@
+package postfx
+
+import (
+	"math"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+var (
+	_ Effect = Mosaic{}
+	_ Effect = Wave{}
+	_ Effect = Scanlines{}
+	_ Effect = &Flash{}
+	_ Effect = Remap{}
+	_ Effect = Chromatic{}
+)
+
+// Pixelate the frame by filling blocks of pixels with a single color.
+type Mosaic struct {
+	// The size of the block in pixels. Values below 2 disable the effect.
+	Size int
+}
+
+// Apply implements [Effect].
+func (m Mosaic) Apply(f *Frame) {
+	if m.Size < 2 {
+		return
+	}
+	for by := 0; by < firefly.Height; by += m.Size {
+		for bx := 0; bx < firefly.Width; bx += m.Size {
+			// Take the color from the block center to keep thin details centered.
+			cx := min(bx+m.Size/2, firefly.Width-1)
+			cy := min(by+m.Size/2, firefly.Height-1)
+			c := f.pixel(cy*firefly.Width + cx)
+			for y := by; y < min(by+m.Size, firefly.Height); y++ {
+				for x := bx; x < min(bx+m.Size, firefly.Width); x++ {
+					f.setPixel(y*firefly.Width+x, c)
+				}
+			}
+		}
+	}
+}
+
+// Horizontal wave distortion: every row is shifted left or right by a sine wave.
+//
+// Looks like heat haze, underwater view, or a dream sequence.
+type Wave struct {
+	// The maximum horizontal shift in pixels.
+	Amplitude int
+
+	// The number of rows in one full wave. The default is 32.
+	Wavelength int
+
+	// How many rows the wave moves up on every frame. Can be negative.
+	Speed float32
+}
+
+// Apply implements [Effect].
+func (w Wave) Apply(f *Frame) {
+	if w.Amplitude == 0 {
+		return
+	}
+	wavelength := w.Wavelength
+	if wavelength <= 0 {
+		wavelength = 32
+	}
+	var row [firefly.Width]firefly.Color
+	phase := float32(f.Count) * w.Speed
+	for y := range firefly.Height {
+		t := (float32(y) + phase) / float32(wavelength)
+		shift := int(tinymath.Round(float32(w.Amplitude) * tinymath.Sin(2*math.Pi*t)))
+		if shift == 0 {
+			continue
+		}
+		start := y * firefly.Width
+		for x := range row {
+			row[x] = f.pixel(start + x)
+		}
+		for x := range row {
+			// Pixels shifted in from outside of the screen repeat the edge pixel.
+			src := min(max(x-shift, 0), firefly.Width-1)
+			f.setPixel(start+x, row[src])
+		}
+	}
+}
+
+// Darken every second row to imitate the look of an old CRT screen.
+//
+// Since there are only 16 colors, darkening is done by replacing
+// every color with a darker color from the palette.
+type Scanlines struct {
+	// The distance between darkened rows. The default is 2 (every second row).
+	Spacing int
+
+	// The map from colors to their darker versions.
+	//
+	// If nil, [Darken] for the default palette is used.
+	Map *Remap
+}
+
+// The default map for [Scanlines].
+var defaultDarken = Darken(defaultPalette, 0.5)
+
+// Apply implements [Effect].
+func (s Scanlines) Apply(f *Frame) {
+	spacing := s.Spacing
+	if spacing <= 0 {
+		spacing = 2
+	}
+	remap := s.Map
+	if remap == nil {
+		remap = &defaultDarken
+	}
+	table := remap.packed()
+	for y := spacing - 1; y < firefly.Height; y += spacing {
+		row := f.row(y)
+		for i, b := range row {
+			row[i] = table[b]
+		}
+	}
+}
+
+// Fill the screen with a single color for a few frames.
+//
+// Start it with [Flash.Start]. If the player enabled [firefly.Settings.ReduceFlashing],
+// the flash is not shown at all.
+type Flash struct {
+	// The color of the flash.
+	Color firefly.Color
+
+	frames int
+}
+
+// Show the flash for the given number of frames.
+func (fl *Flash) Start(frames int) {
+	fl.frames = frames
+}
+
+// Stop the flash if it's active.
+func (fl *Flash) Stop() {
+	fl.frames = 0
+}
+
+// Check if the flash is currently active.
+func (fl *Flash) Active() bool {
+	return fl.frames > 0
+}
+
+// Apply implements [Effect].
+func (fl *Flash) Apply(f *Frame) {
+	if fl.frames <= 0 {
+		return
+	}
+	fl.frames--
+	if f.ReduceFlashing {
+		return
+	}
+	f.Fill(fl.Color)
+}
+
+// Color fringes on the edges of objects, like a misaligned lens or a broken TV.
+//
+// The true chromatic aberration shifts the color channels apart, which isn't possible
+// with a 16-color palette. Instead, the frame is shifted to the left and to the right,
+// the colors of each shifted copy are offset in the palette using a [Remap],
+// and the copies are shown where they differ from the frame.
+type Chromatic struct {
+	// How far (in pixels) the copies are shifted.
+	Offset int
+
+	// The palette offset of the copy shifted to the left.
+	//
+	// Colors mapped to [firefly.ColorNone] don't produce fringes.
+	// If nil, the colors are tinted red.
+	Left *Remap
+
+	// The palette offset of the copy shifted to the right.
+	//
+	// Colors mapped to [firefly.ColorNone] don't produce fringes.
+	// If nil, the colors are tinted blue.
+	Right *Remap
+}
+
+// The default maps for [Chromatic].
+var (
+	defaultLeft  = Tint(defaultPalette, firefly.NewRGB(0xff, 0x00, 0x00), 0.5)
+	defaultRight = Tint(defaultPalette, firefly.NewRGB(0x00, 0x00, 0xff), 0.5)
+)
+
+// Apply implements [Effect].
+func (c Chromatic) Apply(f *Frame) {
+	if c.Offset <= 0 {
+		return
+	}
+	left := c.Left
+	if left == nil {
+		left = &defaultLeft
+	}
+	right := c.Right
+	if right == nil {
+		right = &defaultRight
+	}
+	var row [firefly.Width]firefly.Color
+	for y := range firefly.Height {
+		start := y * firefly.Width
+		for x := range row {
+			row[x] = f.pixel(start + x)
+		}
+		for x, color := range row {
+			fringe := firefly.ColorNone
+			if x+c.Offset < firefly.Width && row[x+c.Offset] != color {
+				fringe = left.Get(row[x+c.Offset])
+			}
+			if fringe == firefly.ColorNone && x-c.Offset >= 0 && row[x-c.Offset] != color {
+				fringe = right.Get(row[x-c.Offset])
+			}
+			f.setPixel(start+x, fringe)
+		}
+	}
+}
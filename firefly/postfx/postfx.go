@@ -0,0 +1,187 @@
+// Full-screen post-processing effects.
+//
+// The [Pipeline] makes all drawing functions target a full-frame canvas
+// instead of the screen. When the frame is drawn, the effects are applied
+// one by one to the canvas memory on the Go side, and then the canvas
+// is drawn on the screen with a single [firefly.DrawImage] call.
+//
+// So, the effects can be added to an existing game without changing
+// any of its drawing code:
+//
+//	func init() {
+//		firefly.Render = render
+//		pipeline := postfx.New(&postfx.Scanlines{})
+//		pipeline.Install()
+//	}
+package postfx
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// A post-processing effect.
+//
+// Effects modify the frame in place.
+type Effect interface {
+	Apply(f *Frame)
+}
+
+// The size of the image header in the canvas memory.
+const headerSize = 4
+
+// The full-screen frame that effects are applied to.
+type Frame struct {
+	canvas firefly.Canvas
+
+	// The canvas memory without the header, 2 pixels per byte.
+	//
+	// Effects work with it directly instead of [firefly.Canvas.GetPixel]
+	// to avoid parsing the header for every pixel.
+	body []byte
+
+	// The number of frames rendered since the pipeline was created.
+	//
+	// Used by animated effects.
+	Count int
+
+	// The player has photosensitivity and the effects should avoid flashes.
+	//
+	// Copied from [firefly.Settings.ReduceFlashing] on every frame.
+	ReduceFlashing bool
+}
+
+// Create a new frame backed by a new screen-sized canvas.
+//
+// Normally, you don't need to create frames manually, [Pipeline] does it for you.
+// It's exposed to make it possible to test and combine custom effects.
+func NewFrame() *Frame {
+	canvas := firefly.NewCanvas(firefly.S(firefly.Width, firefly.Height))
+	return &Frame{canvas: canvas, body: canvas.Image().Bytes()[headerSize:]}
+}
+
+// The canvas on which the frame is drawn.
+func (f *Frame) Canvas() firefly.Canvas {
+	return f.canvas
+}
+
+// Get the color of the pixel.
+//
+// Returns [firefly.ColorNone] for points outside of the screen.
+func (f *Frame) Get(x, y int) firefly.Color {
+	if x < 0 || y < 0 || x >= firefly.Width || y >= firefly.Height {
+		return firefly.ColorNone
+	}
+	return f.pixel(y*firefly.Width + x)
+}
+
+// Set the color of the pixel.
+//
+// Points outside of the screen and [firefly.ColorNone] are ignored.
+func (f *Frame) Set(x, y int, c firefly.Color) {
+	if x < 0 || y < 0 || x >= firefly.Width || y >= firefly.Height {
+		return
+	}
+	f.setPixel(y*firefly.Width+x, c)
+}
+
+// Get the color of the pixel with the given index, without bounds checks.
+func (f *Frame) pixel(i int) firefly.Color {
+	b := f.body[i/2]
+	if i%2 == 0 {
+		b >>= 4
+	}
+	return firefly.Color(b&0b1111 + 1)
+}
+
+// Set the color of the pixel with the given index, without bounds checks.
+func (f *Frame) setPixel(i int, c firefly.Color) {
+	if c == firefly.ColorNone {
+		return
+	}
+	v := byte(c-1) & 0b1111
+	b := &f.body[i/2]
+	if i%2 == 0 {
+		*b = *b&0b1111 | v<<4
+	} else {
+		*b = *b&0b11110000 | v
+	}
+}
+
+// The packed pixels of the row, 2 pixels per byte.
+//
+// The screen width is even, so every row starts at a byte boundary.
+func (f *Frame) row(y int) []byte {
+	const rowSize = firefly.Width / 2
+	return f.body[y*rowSize : (y+1)*rowSize]
+}
+
+// Fill the whole frame with the color.
+func (f *Frame) Fill(c firefly.Color) {
+	f.canvas.Fill(c)
+}
+
+// Render the frame using a chain of effects.
+//
+// Constructed by [New].
+type Pipeline struct {
+	// Effects applied to the frame, in order.
+	//
+	// Can be modified at any time, for example, to turn an effect on or off.
+	Effects []Effect
+
+	// The function that draws the frame.
+	//
+	// Set by [Pipeline.Install] to the previous value of [firefly.Render].
+	Draw func()
+
+	frame *Frame
+}
+
+// Create a new pipeline with the given effects.
+func New(effects ...Effect) *Pipeline {
+	return &Pipeline{Effects: effects, frame: NewFrame()}
+}
+
+// Wrap the current [firefly.Render] callback with the pipeline.
+//
+// Must be called after the render callback is set.
+func (p *Pipeline) Install() {
+	p.Draw = firefly.Render
+	firefly.Render = p.Render
+}
+
+// Draw the frame on the canvas, apply the effects, and draw the result on the screen.
+//
+// If there are no effects, the frame is drawn directly on the screen.
+func (p *Pipeline) Render() {
+	if p.Draw == nil {
+		return
+	}
+	if len(p.Effects) == 0 {
+		p.Draw()
+		p.frame.Count++
+		return
+	}
+	p.frame.canvas.Set()
+	p.Draw()
+	firefly.UnsetCanvas()
+	settings := firefly.GetSettings(firefly.GetMe())
+	p.frame.ReduceFlashing = settings.ReduceFlashing
+	p.Apply()
+	firefly.DrawImage(p.frame.canvas.Image(), firefly.Point{})
+}
+
+// Apply all effects to the current frame without drawing it.
+//
+// Called by [Pipeline.Render].
+func (p *Pipeline) Apply() {
+	for _, effect := range p.Effects {
+		effect.Apply(p.frame)
+	}
+	p.frame.Count++
+}
+
+// The frame the pipeline draws into.
+func (p *Pipeline) Frame() *Frame {
+	return p.frame
+}
@@ -0,0 +1,132 @@
+package postfx_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/postfx"
+)
+
+func TestShift(t *testing.T) {
+	t.Parallel()
+	r := postfx.Shift(firefly.ColorBlack, firefly.ColorYellow, 1)
+	tests := []struct {
+		from firefly.Color
+		want firefly.Color
+	}{
+		{from: firefly.ColorNone, want: firefly.ColorNone},
+		{from: firefly.ColorBlack, want: firefly.ColorPurple},
+		{from: firefly.ColorOrange, want: firefly.ColorYellow},
+		{from: firefly.ColorYellow, want: firefly.ColorBlack},
+		{from: firefly.ColorWhite, want: firefly.ColorWhite},
+	}
+	for _, test := range tests {
+		got := r.Get(test.from)
+		if got != test.want {
+			t.Errorf("%s: want %s, but got %s", test.from, test.want, got)
+		}
+	}
+}
+
+func TestMosaic(t *testing.T) {
+	t.Parallel()
+	f := postfx.NewFrame()
+	f.Fill(firefly.ColorBlack)
+	f.Set(2, 2, firefly.ColorRed)
+	postfx.Mosaic{Size: 4}.Apply(f)
+	for y := range 4 {
+		for x := range 4 {
+			if f.Get(x, y) != firefly.ColorRed {
+				t.Errorf("{%d, %d}: want Red, but got %s", x, y, f.Get(x, y))
+			}
+		}
+	}
+	if f.Get(4, 4) != firefly.ColorBlack {
+		t.Errorf("the next block must not change")
+	}
+}
+
+func TestFlash(t *testing.T) {
+	t.Parallel()
+	f := postfx.NewFrame()
+	f.Fill(firefly.ColorBlack)
+	flash := &postfx.Flash{Color: firefly.ColorWhite}
+	flash.Start(1)
+
+	f.ReduceFlashing = true
+	flash.Apply(f)
+	if f.Get(0, 0) != firefly.ColorBlack {
+		t.Errorf("the flash must be suppressed")
+	}
+
+	f.ReduceFlashing = false
+	flash.Start(1)
+	flash.Apply(f)
+	if f.Get(0, 0) != firefly.ColorWhite {
+		t.Errorf("the flash must be shown")
+	}
+	if flash.Active() {
+		t.Errorf("the flash must stop after one frame")
+	}
+}
+
+func TestChromatic(t *testing.T) {
+	t.Parallel()
+	f := postfx.NewFrame()
+	f.Fill(firefly.ColorBlack)
+	f.Set(10, 0, firefly.ColorWhite)
+	// The background doesn't produce fringes, white is offset to red and cyan.
+	left := postfx.Identity().With(firefly.ColorBlack, firefly.ColorNone).With(firefly.ColorWhite, firefly.ColorRed)
+	right := postfx.Identity().With(firefly.ColorBlack, firefly.ColorNone).With(firefly.ColorWhite, firefly.ColorCyan)
+	effect := postfx.Chromatic{Offset: 1, Left: &left, Right: &right}
+	effect.Apply(f)
+	want := []firefly.Color{
+		firefly.ColorBlack, firefly.ColorRed, firefly.ColorWhite, firefly.ColorCyan, firefly.ColorBlack,
+	}
+	for i, w := range want {
+		got := f.Get(8+i, 0)
+		if got != w {
+			t.Errorf("x=%d: want %s, but got %s", 8+i, w, got)
+		}
+	}
+}
+
+func TestRemap_Apply(t *testing.T) {
+	t.Parallel()
+	f := postfx.NewFrame()
+	f.Fill(firefly.ColorBlack)
+	f.Set(3, 1, firefly.ColorRed)
+	f.Set(4, 1, firefly.ColorBlue)
+	r := postfx.Identity().With(firefly.ColorRed, firefly.ColorGreen).With(firefly.ColorBlue, firefly.ColorNone)
+	r.Apply(f)
+	tests := []struct {
+		point firefly.Point
+		want  firefly.Color
+	}{
+		{point: firefly.P(3, 1), want: firefly.ColorGreen},
+		{point: firefly.P(4, 1), want: firefly.ColorBlue},
+		{point: firefly.P(5, 1), want: firefly.ColorBlack},
+	}
+	for _, test := range tests {
+		got := f.Get(test.point.X, test.point.Y)
+		if got != test.want {
+			t.Errorf("%v: want %s, but got %s", test.point, test.want, got)
+		}
+	}
+}
+
+func TestScanlines(t *testing.T) {
+	t.Parallel()
+	f := postfx.NewFrame()
+	f.Fill(firefly.ColorWhite)
+	darken := postfx.Identity().With(firefly.ColorWhite, firefly.ColorGray)
+	postfx.Scanlines{Map: &darken}.Apply(f)
+	for x := range 3 {
+		if got := f.Get(x, 0); got != firefly.ColorWhite {
+			t.Errorf("{%d, 0}: want White, but got %s", x, got)
+		}
+		if got := f.Get(x, 1); got != firefly.ColorGray {
+			t.Errorf("{%d, 1}: want Gray, but got %s", x, got)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package postfx
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/palette"
+)
+
+// The palette used for building the default color maps.
+var defaultPalette = palette.Sweetie16
+
+// A map that replaces every color with another color.
+//
+// The index is the source color, the value is the target color.
+// Can be used as an [Effect] on its own to recolor the whole frame,
+// for example, to make a "night" version of a level.
+//
+// The zero value maps all colors to [firefly.ColorNone] which is ignored,
+// so start with [Identity] or one of the constructors.
+type Remap [17]firefly.Color
+
+// A map that doesn't change any colors.
+func Identity() Remap {
+	var r Remap
+	for i := range r {
+		r[i] = firefly.Color(i)
+	}
+	return r
+}
+
+// Map every color to the palette color closest to its darker version.
+//
+// The ratio k is how much darker the colors are, from 0.0 (no change) to 1.0 (black).
+func Darken(p palette.Palette, k float32) Remap {
+	return Tint(p, firefly.RGB{}, k)
+}
+
+// Map every color to the palette color closest to its lighter version.
+//
+// The ratio k is how much lighter the colors are, from 0.0 (no change) to 1.0 (white).
+func Lighten(p palette.Palette, k float32) Remap {
+	return Tint(p, firefly.NewRGB(0xff, 0xff, 0xff), k)
+}
+
+// Map every color to the palette color closest to its mix with the given color.
+func Tint(p palette.Palette, v firefly.RGB, k float32) Remap {
+	r := Identity()
+	tinted := p.Tint(v, k)
+	for i, c := range tinted {
+		r[i+1] = p.Nearest(c)
+	}
+	return r
+}
+
+// Shift colors from the range by the offset, wrapping around.
+//
+// For example, shifting the range from [firefly.ColorBlack] to [firefly.ColorYellow]
+// by one maps black to purple, purple to red, and so on, and yellow to black.
+// Colors outside of the range aren't changed.
+func Shift(first, last firefly.Color, offset int) Remap {
+	r := Identity()
+	first = max(first, firefly.ColorBlack)
+	last = min(last, firefly.ColorDarkGray)
+	if last < first {
+		return r
+	}
+	size := int(last-first) + 1
+	offset = (offset%size + size) % size
+	for i := range size {
+		r[int(first)+i] = first + firefly.Color((i+offset)%size)
+	}
+	return r
+}
+
+// Make a copy of the map with the color from replaced by the color to.
+func (r Remap) With(from, to firefly.Color) Remap {
+	if int(from) < len(r) {
+		r[from] = to
+	}
+	return r
+}
+
+// Get the color that the given color is mapped to.
+func (r Remap) Get(c firefly.Color) firefly.Color {
+	if int(c) >= len(r) {
+		return c
+	}
+	return r[c]
+}
+
+// Apply implements [Effect].
+func (r Remap) Apply(f *Frame) {
+	table := r.packed()
+	for i, b := range f.body {
+		f.body[i] = table[b]
+	}
+}
+
+// The map for every possible byte of 2 packed pixels.
+//
+// Colors mapped to [firefly.ColorNone] aren't changed.
+func (r Remap) packed() [256]byte {
+	var table [256]byte
+	for i := range table {
+		b := byte(i)
+		table[i] = r.nibble(b>>4)<<4 | r.nibble(b&0b1111)
+	}
+	return table
+}
+
+// Map the color stored as a nibble of the packed pixels.
+func (r Remap) nibble(v byte) byte {
+	c := r.Get(firefly.Color(v + 1))
+	if c == firefly.ColorNone {
+		return v
+	}
+	return byte(c-1) & 0b1111
+}
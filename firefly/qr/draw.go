@@ -0,0 +1,110 @@
+package qr
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// How to render a [Code].
+type Style struct {
+	// The width and the height of a single module in pixels.
+	//
+	// Values less than 1 are treated as 1.
+	Scale int
+
+	// The width of the light border around the code in modules.
+	//
+	// Most readers need at least 1 module, the standard requires 4.
+	Quiet int
+
+	// The color of dark modules.
+	Dark firefly.Color
+
+	// The color of light modules and of the quiet zone.
+	//
+	// If [firefly.ColorNone], light modules are not drawn.
+	Light firefly.Color
+}
+
+// The style that produces codes readable by most scanners.
+func DefaultStyle() Style {
+	return Style{
+		Scale: 2,
+		Quiet: 2,
+		Dark:  firefly.ColorBlack,
+		Light: firefly.ColorWhite,
+	}
+}
+
+func (s Style) scale() int {
+	return max(s.Scale, 1)
+}
+
+// The width (and the height) of the rendered code in pixels, including the quiet zone.
+func (c *Code) PixelSize(s Style) int {
+	return (c.size + max(s.Quiet, 0)*2) * s.scale()
+}
+
+// Render the code on the screen with the top-left corner at the given point.
+//
+// Horizontal runs of dark modules are merged, so the code is drawn
+// with much fewer host calls than one per module.
+func (c *Code) Draw(p firefly.Point, s Style) {
+	scale := s.scale()
+	full := c.PixelSize(s)
+	if s.Light != firefly.ColorNone {
+		firefly.DrawRect(p, firefly.S(full, full), firefly.Solid(s.Light))
+	}
+	origin := p.Add(firefly.P(max(s.Quiet, 0)*scale, max(s.Quiet, 0)*scale))
+	style := firefly.Solid(s.Dark)
+	for y := range c.size {
+		for x := 0; x < c.size; x++ {
+			if !c.Get(x, y) {
+				continue
+			}
+			start := x
+			for x+1 < c.size && c.Get(x+1, y) {
+				x++
+			}
+			point := origin.Add(firefly.P(start*scale, y*scale))
+			firefly.DrawRect(point, firefly.S((x-start+1)*scale, scale), style)
+		}
+	}
+}
+
+// Render the code into the canvas with the top-left corner at the given point.
+//
+// Doesn't call the host, so it's fine to do it outside of render,
+// for example once when the game starts.
+func (c *Code) DrawOn(canvas firefly.Canvas, p firefly.Point, s Style) {
+	scale := s.scale()
+	quiet := max(s.Quiet, 0)
+	full := c.PixelSize(s)
+	for py := range full {
+		y := py/scale - quiet
+		for px := range full {
+			x := px/scale - quiet
+			color := s.Light
+			if c.Get(x, y) {
+				color = s.Dark
+			}
+			canvas.SetPixel(p.Add(firefly.P(px, py)), color)
+		}
+	}
+}
+
+// Render the code into a new canvas of the [Code.PixelSize] size.
+//
+// If [Style].Light is [firefly.ColorNone], the light modules are transparent.
+func (c *Code) Canvas(s Style) firefly.Canvas {
+	full := c.PixelSize(s)
+	canvas := firefly.NewCanvas(firefly.S(full, full))
+	if s.Light == firefly.ColorNone {
+		// Any color other than the dark one works for transparency.
+		transp := firefly.ColorBlack
+		if s.Dark == transp {
+			transp = firefly.ColorWhite
+		}
+		canvas.Image().SetTransparency(transp)
+		canvas.Fill(transp)
+	}
+	c.DrawOn(canvas, firefly.P(0, 0), s)
+	return canvas
+}
@@ -0,0 +1,211 @@
+package qr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The characters supported by the alphanumeric mode, in the order of their codes.
+const alphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// The encoding mode of the data.
+type mode uint8
+
+const (
+	numericMode      mode = 0b0001
+	alphanumericMode mode = 0b0010
+	byteMode         mode = 0b0100
+)
+
+// Pick the most compact mode that can encode the whole text.
+func detectMode(text string) mode {
+	numeric := true
+	for i := range len(text) {
+		c := text[i]
+		if c < '0' || c > '9' {
+			numeric = false
+		}
+		if strings.IndexByte(alphanumeric, c) < 0 {
+			return byteMode
+		}
+	}
+	if numeric {
+		return numericMode
+	}
+	return alphanumericMode
+}
+
+// The number of bits used for the character count.
+func (m mode) countBits(version int) int {
+	small := version < 10
+	switch m {
+	case numericMode:
+		if small {
+			return 10
+		}
+		return 12
+	case alphanumericMode:
+		if small {
+			return 9
+		}
+		return 11
+	case byteMode:
+		if small {
+			return 8
+		}
+		return 16
+	}
+	return 0
+}
+
+// A sequence of bits being written.
+type bitBuffer struct {
+	bytes []byte
+	len   int
+}
+
+// Append n lowest bits of the value, the most significant first.
+func (b *bitBuffer) write(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.len%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if value>>i&1 == 1 {
+			b.bytes[b.len/8] |= 0x80 >> (b.len % 8)
+		}
+		b.len++
+	}
+}
+
+// Encode the text into data codewords, including the padding.
+func encodeData(text string, level Level, version int) ([]byte, error) {
+	m := detectMode(text)
+	var buf bitBuffer
+	buf.write(int(m), 4)
+	countBits := m.countBits(version)
+	if len(text) >= 1<<countBits {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(text))
+	}
+	buf.write(len(text), countBits)
+	switch m {
+	case numericMode:
+		for i := 0; i < len(text); i += 3 {
+			chunk := text[i:min(i+3, len(text))]
+			value := 0
+			for _, c := range []byte(chunk) {
+				value = value*10 + int(c-'0')
+			}
+			// 3 digits take 10 bits, 2 digits take 7 bits, 1 digit takes 4 bits.
+			buf.write(value, len(chunk)*3+1)
+		}
+	case alphanumericMode:
+		for i := 0; i < len(text); i += 2 {
+			value := strings.IndexByte(alphanumeric, text[i])
+			if i+1 < len(text) {
+				value = value*45 + strings.IndexByte(alphanumeric, text[i+1])
+				buf.write(value, 11)
+			} else {
+				buf.write(value, 6)
+			}
+		}
+	case byteMode:
+		for i := range len(text) {
+			buf.write(int(text[i]), 8)
+		}
+	}
+
+	capacity := blockTable[version-1][level].dataLen() * 8
+	if buf.len > capacity {
+		return nil, fmt.Errorf("%w: %d bits for version %d", ErrTooLong, buf.len, version)
+	}
+	// The terminator is up to 4 zero bits, and then zeros up to the byte boundary.
+	buf.write(0, min(4, capacity-buf.len))
+	buf.write(0, (8-buf.len%8)%8)
+	// The rest is filled by alternating padding bytes.
+	for pad := 0xEC; buf.len < capacity; pad ^= 0xEC ^ 0x11 {
+		buf.write(pad, 8)
+	}
+	return buf.bytes, nil
+}
+
+// Split the data into blocks, add error correction codewords to each,
+// and interleave the blocks.
+func addECC(data []byte, level Level, version int) []byte {
+	b := blockTable[version-1][level]
+	gen := generator(b.ecc)
+	dataBlocks := make([][]byte, b.count())
+	eccBlocks := make([][]byte, b.count())
+	offset := 0
+	for i := range dataBlocks {
+		size := b.data1
+		if i >= b.count1 {
+			size++
+		}
+		dataBlocks[i] = data[offset : offset+size]
+		eccBlocks[i] = remainder(dataBlocks[i], gen)
+		offset += size
+	}
+
+	result := make([]byte, 0, len(data)+b.ecc*b.count())
+	for i := range b.data1 + 1 {
+		for _, block := range dataBlocks {
+			// Blocks of the first group are one codeword shorter.
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := range b.ecc {
+		for _, block := range eccBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// Multiply two elements of GF(256) with the QR code polynomial 0x11D.
+func gfMul(x, y byte) byte {
+	var z byte
+	for i := 7; i >= 0; i-- {
+		// Multiply z by 2 and reduce by the field polynomial.
+		z = z<<1 ^ (z>>7)*0x1D
+		z ^= (y >> i & 1) * x
+	}
+	return z
+}
+
+// The Reed-Solomon generator polynomial of the given degree.
+//
+// The coefficients go from the highest power to the lowest,
+// and the leading coefficient (always 1) is omitted.
+func generator(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	// The root is 2 to the power of i.
+	var root byte = 1
+	for range degree {
+		// Multiply the polynomial by (x - root).
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return result
+}
+
+// The Reed-Solomon error correction codewords for the data.
+func remainder(data, gen []byte) []byte {
+	result := make([]byte, len(gen))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range gen {
+			result[i] ^= gfMul(coef, factor)
+		}
+	}
+	return result
+}
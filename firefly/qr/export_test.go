@@ -0,0 +1,10 @@
+package qr
+
+// The final sequence of codewords for the text, before it's placed on the matrix.
+func Codewords(text string, level Level, version int) ([]byte, error) {
+	data, err := encodeData(text, level, version)
+	if err != nil {
+		return nil, err
+	}
+	return addECC(data, level, version), nil
+}
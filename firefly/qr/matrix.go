@@ -0,0 +1,289 @@
+package qr
+
+// Create a code with all function patterns drawn.
+func newCode(version int, level Level) *Code {
+	size := version*4 + 17
+	c := &Code{
+		Version:  version,
+		Level:    level,
+		size:     size,
+		modules:  make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+	c.drawFunctionPatterns()
+	return c
+}
+
+// Check if the module is not data: finders, timing, alignment, format, or version.
+//
+// Data placement and masks must not touch these modules.
+func (c *Code) isFunction(x, y int) bool {
+	return c.function[y*c.size+x]
+}
+
+func (c *Code) drawFunctionPatterns() {
+	setFunction := func(x, y int, dark bool) {
+		c.set(x, y, dark)
+		c.function[y*c.size+x] = true
+	}
+
+	// Timing patterns.
+	for i := range c.size {
+		setFunction(6, i, i%2 == 0)
+		setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with separators.
+	for _, corner := range [3][2]int{{3, 3}, {c.size - 4, 3}, {3, c.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x < 0 || y < 0 || x >= c.size || y >= c.size {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				setFunction(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+
+	// Alignment patterns.
+	centers := alignmentTable[c.Version-1]
+	last := len(centers) - 1
+	for i, cy := range centers {
+		for j, cx := range centers {
+			// Skip the three corners occupied by finder patterns.
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas. The actual bits depend on the mask
+	// and are drawn by drawFormat.
+	for i := range 9 {
+		// Skip the timing patterns crossing the area.
+		if i != 6 {
+			setFunction(8, i, false)
+			setFunction(i, 8, false)
+		}
+	}
+	for i := range 8 {
+		setFunction(c.size-1-i, 8, false)
+		setFunction(8, c.size-1-i, false)
+	}
+	// The dark module.
+	setFunction(8, c.size-8, true)
+
+	if c.Version >= 7 {
+		bits := versionBits(c.Version)
+		for i := range 18 {
+			dark := bits>>i&1 == 1
+			a, b := c.size-11+i%3, i/3
+			setFunction(a, b, dark)
+			setFunction(b, a, dark)
+		}
+	}
+}
+
+// The version information with the BCH error correction bits.
+func versionBits(version int) int {
+	rem := version
+	for range 12 {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return version<<12 | rem
+}
+
+// The format information with the BCH error correction bits and the mask applied.
+func formatBits(level Level, mask int) int {
+	// The level bits are not in the order of levels: L=01, M=00, Q=11, H=10.
+	data := int(level^1)<<3 | mask
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// Draw both copies of the format information.
+func (c *Code) drawFormat() {
+	bits := formatBits(c.Level, c.Mask)
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	// The copy around the top-left finder pattern.
+	for i := range 6 {
+		c.set(8, i, bit(i))
+	}
+	c.set(8, 7, bit(6))
+	c.set(8, 8, bit(7))
+	c.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.set(14-i, 8, bit(i))
+	}
+
+	// The copy split between the other two finder patterns.
+	for i := range 8 {
+		c.set(c.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.set(8, c.size-15+i, bit(i))
+	}
+}
+
+// Place the codewords in the zigzag order, two columns at a time,
+// going from the bottom-right corner.
+func (c *Code) place(codewords []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		// The vertical timing pattern is skipped entirely.
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.size {
+			y := vert
+			if upward {
+				y = c.size - 1 - vert
+			}
+			for j := range 2 {
+				x := right - j
+				if c.isFunction(x, y) || i >= len(codewords)*8 {
+					continue
+				}
+				c.set(x, y, codewords[i/8]>>(7-i%8)&1 == 1)
+				i++
+			}
+		}
+	}
+}
+
+// Check if the mask inverts the module.
+func masked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// Invert data modules covered by the mask.
+//
+// Applying the same mask twice restores the original modules.
+func (c *Code) applyMask(mask int) {
+	for y := range c.size {
+		for x := range c.size {
+			if !c.isFunction(x, y) && masked(mask, x, y) {
+				c.set(x, y, !c.Get(x, y))
+			}
+		}
+	}
+}
+
+// Try all masks and keep the one with the lowest penalty.
+func (c *Code) applyBestMask() {
+	best := 0
+	bestPenalty := -1
+	for mask := range 8 {
+		c.Mask = mask
+		c.applyMask(mask)
+		c.drawFormat()
+		penalty := c.penalty()
+		if bestPenalty < 0 || penalty < bestPenalty {
+			best = mask
+			bestPenalty = penalty
+		}
+		c.applyMask(mask)
+	}
+	c.Mask = best
+	c.applyMask(best)
+	c.drawFormat()
+}
+
+// Penalty points for patterns that make the code harder to read.
+func (c *Code) penalty() int {
+	result := 0
+	dark := 0
+	for i := range c.size {
+		row := func(j int) bool { return c.Get(j, i) }
+		col := func(j int) bool { return c.Get(i, j) }
+		result += c.linePenalty(row) + c.linePenalty(col)
+		for j := range c.size {
+			if c.Get(j, i) {
+				dark++
+			}
+			// 2x2 blocks of the same color.
+			if i > 0 && j > 0 {
+				m := c.Get(j, i)
+				if m == c.Get(j-1, i) && m == c.Get(j, i-1) && m == c.Get(j-1, i-1) {
+					result += 3
+				}
+			}
+		}
+	}
+	// The proportion of dark modules deviating from 50%, in steps of 5%.
+	total := c.size * c.size
+	deviation := abs(dark*20-total*10) / total
+	result += deviation * 10
+	return result
+}
+
+// Penalty points for a single row or column.
+func (c *Code) linePenalty(get func(int) bool) int {
+	result := 0
+	// Runs of 5 or more modules of the same color.
+	run := 0
+	for i := range c.size {
+		if i > 0 && get(i) == get(i-1) {
+			run++
+		} else {
+			run = 1
+		}
+		if run == 5 {
+			result += 3
+		} else if run > 5 {
+			result++
+		}
+	}
+	// Patterns looking like finders: dark-light-dark-dark-dark-light-dark
+	// with 4 light modules on either side.
+	const finder = 0b1011101
+	for i := 0; i+11 <= c.size; i++ {
+		bits := 0
+		for j := range 11 {
+			bits <<= 1
+			if get(i + j) {
+				bits |= 1
+			}
+		}
+		if bits == finder || bits == finder<<4 {
+			result += 40
+		}
+	}
+	return result
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,111 @@
+// Pure Go QR code encoder.
+//
+// Unlike [firefly.DrawQR], which delegates everything to the host,
+// the encoder lets you choose the error correction level, the version, the module size,
+// and the quiet zone, and can render the code into a [firefly.Canvas].
+//
+// Supported are numeric, alphanumeric, and byte modes,
+// all error correction levels, and versions from 1 to 10
+// (up to 271 bytes of text).
+package qr
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTooLong        = errors.New("the text doesn't fit into a QR code")
+	ErrInvalidVersion = errors.New("the QR code version must be between 1 and 10")
+)
+
+const (
+	// The smallest supported version.
+	MinVersion = 1
+
+	// The biggest supported version.
+	MaxVersion = 10
+)
+
+// Error correction level.
+//
+// The higher the level, the bigger the part of the code
+// that can be damaged and still be readable, but the less data fits into the code.
+type Level uint8
+
+const (
+	// Recovers 7% of data.
+	Low Level = iota
+	// Recovers 15% of data.
+	Medium
+	// Recovers 25% of data.
+	Quartile
+	// Recovers 30% of data.
+	High
+)
+
+// An encoded QR code.
+//
+// Constructed by [Encode] or [EncodeVersion].
+type Code struct {
+	// The version of the code, from 1 to 10. Defines the size.
+	Version int
+
+	// The error correction level.
+	Level Level
+
+	// The mask pattern applied to the code, from 0 to 7.
+	Mask int
+
+	size     int
+	modules  []bool
+	function []bool
+}
+
+// Encode the text using the smallest version that fits it.
+func Encode(text string, level Level) (*Code, error) {
+	for version := MinVersion; version <= MaxVersion; version++ {
+		code, err := EncodeVersion(text, level, version)
+		if err == nil {
+			return code, nil
+		}
+		if !errors.Is(err, ErrTooLong) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(text))
+}
+
+// Encode the text using exactly the given version.
+func EncodeVersion(text string, level Level, version int) (*Code, error) {
+	if version < MinVersion || version > MaxVersion {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidVersion, version)
+	}
+	data, err := encodeData(text, level, version)
+	if err != nil {
+		return nil, err
+	}
+	code := newCode(version, level)
+	code.place(addECC(data, level, version))
+	code.applyBestMask()
+	return code, nil
+}
+
+// The width (and the height) of the code in modules, not including the quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Check if the module at the given position is dark.
+//
+// Modules outside of the code are light.
+func (c *Code) Get(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.size || y >= c.size {
+		return false
+	}
+	return c.modules[y*c.size+x]
+}
+
+func (c *Code) set(x, y int, dark bool) {
+	c.modules[y*c.size+x] = dark
+}
@@ -0,0 +1,231 @@
+package qr_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/qr"
+)
+
+func TestCodewords(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		text string
+		want []byte
+	}{
+		// The example from ISO/IEC 18004, annex I.
+		{"01234567", []byte{
+			16, 32, 12, 86, 97, 128, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17,
+			165, 36, 212, 193, 237, 54, 199, 135, 44, 85,
+		}},
+		{"HELLO WORLD", []byte{
+			32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17,
+			196, 35, 39, 119, 235, 215, 231, 226, 93, 23,
+		}},
+	}
+	for _, c := range cases {
+		got, err := qr.Codewords(c.text, qr.Medium, 1)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", c.text, err)
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%q:\nwant %v\ngot  %v", c.text, c.want, got)
+		}
+	}
+}
+
+func TestEncode_Capacity(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		text    string
+		level   qr.Level
+		version int
+	}{
+		{strings.Repeat("1", 41), qr.Low, 1},
+		{strings.Repeat("1", 42), qr.Low, 2},
+		{strings.Repeat("A", 10), qr.High, 1},
+		{strings.Repeat("A", 11), qr.High, 2},
+		{strings.Repeat("a", 14), qr.Medium, 1},
+		{strings.Repeat("a", 15), qr.Medium, 2},
+		{strings.Repeat("a", 271), qr.Low, 10},
+		{strings.Repeat("a", 119), qr.High, 10},
+		{strings.Repeat("1", 652), qr.Low, 10},
+	}
+	for _, c := range cases {
+		code, err := qr.Encode(c.text, c.level)
+		if err != nil {
+			t.Fatalf("%d chars: unexpected error: %v", len(c.text), err)
+		}
+		if code.Version != c.version {
+			t.Errorf("%d chars: want version %d, got %d", len(c.text), c.version, code.Version)
+		}
+		if code.Size() != c.version*4+17 {
+			t.Errorf("%d chars: wrong size %d", len(c.text), code.Size())
+		}
+	}
+
+	_, err := qr.Encode(strings.Repeat("a", 272), qr.Low)
+	if !errors.Is(err, qr.ErrTooLong) {
+		t.Errorf("want ErrTooLong, got %v", err)
+	}
+	_, err = qr.EncodeVersion("a", qr.Low, 11)
+	if !errors.Is(err, qr.ErrInvalidVersion) {
+		t.Errorf("want ErrInvalidVersion, got %v", err)
+	}
+}
+
+// Format information for all levels and masks, from ISO/IEC 18004, table C.1.
+var formats = [4][8]string{
+	qr.Low: {
+		"111011111000100", "111001011110011", "111110110101010", "111100010011101",
+		"110011000101111", "110001100011000", "110110001000001", "110100101110110",
+	},
+	qr.Medium: {
+		"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+		"100010111111001", "100000011001110", "100111110010111", "100101010100000",
+	},
+	qr.Quartile: {
+		"011010101011111", "011000001101000", "011111100110001", "011101000000110",
+		"010010010110100", "010000110000011", "010111011011010", "010101111101101",
+	},
+	qr.High: {
+		"001011010001001", "001001110111110", "001110011100111", "001100111010000",
+		"000011101100010", "000001001010101", "000110100001100", "000100000111011",
+	},
+}
+
+func TestEncode_Format(t *testing.T) {
+	t.Parallel()
+	for level := qr.Low; level <= qr.High; level++ {
+		code, err := qr.Encode("https://fireflyzero.com", level)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		size := code.Size()
+		want := formats[level][code.Mask]
+		// The copy next to the top-right finder has bits from 0 to 7,
+		// the copy next to the bottom-left finder has bits from 8 to 14.
+		var got [15]byte
+		for i := range 8 {
+			got[14-i] = bit(code.Get(size-1-i, 8))
+		}
+		for i := 8; i < 15; i++ {
+			got[14-i] = bit(code.Get(8, size-15+i))
+		}
+		if string(got[:]) != want {
+			t.Errorf("level %d, mask %d: want format %s, got %s", level, code.Mask, want, got[:])
+		}
+		if !code.Get(8, size-8) {
+			t.Errorf("level %d: the dark module must be dark", level)
+		}
+	}
+}
+
+func TestEncode_Version(t *testing.T) {
+	t.Parallel()
+	code, err := qr.EncodeVersion("hello", qr.Low, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Version information for version 7, from ISO/IEC 18004, table D.1.
+	const want = "000111110010010100"
+	size := code.Size()
+	var right, bottom [18]byte
+	for i := range 18 {
+		right[17-i] = bit(code.Get(size-11+i%3, i/3))
+		bottom[17-i] = bit(code.Get(i/3, size-11+i%3))
+	}
+	if string(right[:]) != want || string(bottom[:]) != want {
+		t.Errorf("want version %s, got %s and %s", want, right[:], bottom[:])
+	}
+}
+
+func TestEncode_Finders(t *testing.T) {
+	t.Parallel()
+	code, err := qr.Encode("HELLO WORLD", qr.Quartile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	size := code.Size()
+	for _, corner := range []firefly.Point{{X: 0, Y: 0}, {X: size - 7, Y: 0}, {X: 0, Y: size - 7}} {
+		var rows []string
+		for y := range 7 {
+			row := make([]byte, 7)
+			for x := range 7 {
+				row[x] = bit(code.Get(corner.X+x, corner.Y+y))
+			}
+			rows = append(rows, string(row))
+		}
+		want := []string{"1111111", "1000001", "1011101", "1011101", "1011101", "1000001", "1111111"}
+		if !slices.Equal(rows, want) {
+			t.Errorf("finder at %v: got %v", corner, rows)
+		}
+	}
+	// The timing pattern.
+	for i := 8; i < size-8; i++ {
+		if code.Get(i, 6) != (i%2 == 0) || code.Get(6, i) != (i%2 == 0) {
+			t.Errorf("wrong timing module at %d", i)
+		}
+	}
+}
+
+func TestCode_DrawOn(t *testing.T) {
+	t.Parallel()
+	code, err := qr.Encode("1", qr.Low)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	style := qr.Style{Scale: 2, Quiet: 1, Dark: firefly.ColorBlack, Light: firefly.ColorWhite}
+	if code.PixelSize(style) != (21+2)*2 {
+		t.Fatalf("wrong pixel size %d", code.PixelSize(style))
+	}
+	canvas := code.Canvas(style)
+	cases := []struct {
+		point firefly.Point
+		want  firefly.Color
+	}{
+		{firefly.P(0, 0), firefly.ColorWhite},
+		{firefly.P(1, 1), firefly.ColorWhite},
+		{firefly.P(2, 2), firefly.ColorBlack},
+		{firefly.P(3, 3), firefly.ColorBlack},
+		// The light ring of the finder.
+		{firefly.P(4, 4), firefly.ColorWhite},
+	}
+	for _, c := range cases {
+		got := canvas.GetPixel(c.point)
+		if got != c.want {
+			t.Errorf("pixel %v: want %d, got %d", c.point, c.want, got)
+		}
+	}
+}
+
+func TestCode_Canvas_Transparent(t *testing.T) {
+	t.Parallel()
+	code, err := qr.Encode("1", qr.Low)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, dark := range []firefly.Color{firefly.ColorBlack, firefly.ColorWhite} {
+		canvas := code.Canvas(qr.Style{Scale: 1, Quiet: 1, Dark: dark, Light: firefly.ColorNone})
+		transp := canvas.Image().Transparency()
+		if transp == firefly.ColorNone || transp == dark {
+			t.Fatalf("dark %s: want a transparency color other than dark, got %s", dark, transp)
+		}
+		if got := canvas.GetPixel(firefly.P(0, 0)); got != transp {
+			t.Errorf("dark %s: the quiet zone must be transparent, got %s", dark, got)
+		}
+		if got := canvas.GetPixel(firefly.P(1, 1)); got != dark {
+			t.Errorf("dark %s: the finder must be dark, got %s", dark, got)
+		}
+	}
+}
+
+func bit(dark bool) byte {
+	if dark {
+		return '1'
+	}
+	return '0'
+}
@@ -0,0 +1,59 @@
+package qr
+
+// The layout of error correction blocks for a version and a level.
+//
+// The codewords are split into two groups of blocks.
+// Blocks of the second group have one data codeword more.
+type blocks struct {
+	// Error correction codewords per block.
+	ecc int
+	// The number of blocks in the first group.
+	count1 int
+	// Data codewords per block in the first group.
+	data1 int
+	// The number of blocks in the second group.
+	count2 int
+}
+
+// The number of data codewords in all blocks.
+func (b blocks) dataLen() int {
+	return b.count1*b.data1 + b.count2*(b.data1+1)
+}
+
+// The number of blocks.
+func (b blocks) count() int {
+	return b.count1 + b.count2
+}
+
+// Error correction blocks indexed by version-1 and level.
+//
+// The table is from ISO/IEC 18004, table 9.
+var blockTable = [MaxVersion][4]blocks{
+	{{7, 1, 19, 0}, {10, 1, 16, 0}, {13, 1, 13, 0}, {17, 1, 9, 0}},
+	{{10, 1, 34, 0}, {16, 1, 28, 0}, {22, 1, 22, 0}, {28, 1, 16, 0}},
+	{{15, 1, 55, 0}, {26, 1, 44, 0}, {18, 2, 17, 0}, {22, 2, 13, 0}},
+	{{20, 1, 80, 0}, {18, 2, 32, 0}, {26, 2, 24, 0}, {16, 4, 9, 0}},
+	{{26, 1, 108, 0}, {24, 2, 43, 0}, {18, 2, 15, 2}, {22, 2, 11, 2}},
+	{{18, 2, 68, 0}, {16, 4, 27, 0}, {24, 4, 19, 0}, {28, 4, 15, 0}},
+	{{20, 2, 78, 0}, {18, 4, 31, 0}, {18, 2, 14, 4}, {26, 4, 13, 1}},
+	{{24, 2, 97, 0}, {22, 2, 38, 2}, {22, 4, 18, 2}, {26, 4, 14, 2}},
+	{{30, 2, 116, 0}, {22, 3, 36, 2}, {20, 4, 16, 4}, {24, 4, 12, 4}},
+	{{18, 2, 68, 2}, {26, 4, 43, 1}, {24, 6, 19, 2}, {28, 6, 15, 2}},
+}
+
+// Centers of alignment patterns indexed by version-1.
+//
+// The patterns are placed on all combinations of the coordinates
+// except the ones overlapping finder patterns.
+var alignmentTable = [MaxVersion][]int{
+	nil,
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+}
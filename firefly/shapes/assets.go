@@ -0,0 +1,183 @@
+package shapes
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+var (
+	_ Shape = Text{}
+	_ Shape = Image{}
+	_ Shape = SubImage{}
+	_ Shape = Sprite{}
+)
+
+// A wrapper for [firefly.DrawText].
+type Text struct {
+	Text  string
+	Font  firefly.Font
+	Point firefly.Point
+	Color firefly.Color
+}
+
+// Draw implements [Shape] interface.
+func (s Text) Draw() {
+	firefly.DrawText(s.Text, s.Font, s.Point, s.Color)
+}
+
+// DrawAt implements [Shape] interface.
+func (s Text) DrawAt(offset firefly.Point) {
+	firefly.DrawText(s.Text, s.Font, s.Point.Add(offset), s.Color)
+}
+
+// Bounds implements [Shape] interface.
+//
+// Since [Text.Point] is the baseline start, the bounding box is approximate:
+// it assumes that glyphs are drawn fully above the baseline.
+func (s Text) Bounds() Rect {
+	width := 0
+	lines := 1
+	start := 0
+	for i := range len(s.Text) {
+		if s.Text[i] == '\n' {
+			width = max(width, s.Font.LineWidth(s.Text[start:i]))
+			start = i + 1
+			lines++
+		}
+	}
+	width = max(width, s.Font.LineWidth(s.Text[start:]))
+	h := s.Font.CharHeight()
+	return Rect{
+		Point: s.Point.Sub(firefly.P(0, h)),
+		Size:  firefly.S(width, h*lines),
+	}
+}
+
+// Contains implements [Shape] interface.
+func (s Text) Contains(p firefly.Point) bool {
+	return s.Bounds().Contains(p)
+}
+
+// Move the shape by the given offset.
+func (s Text) Translate(d firefly.Point) Text {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Eq reports if both shapes are the same text drawn with the same font.
+func (s Text) Eq(other Shape) bool {
+	o, ok := other.(Text)
+	return ok && s.Text == o.Text && s.Point == o.Point &&
+		s.Color == o.Color && s.Font.Eq(o.Font)
+}
+
+// A wrapper for [firefly.DrawImage].
+type Image struct {
+	Image firefly.Image
+	Point firefly.Point
+}
+
+// Draw implements [Shape] interface.
+func (s Image) Draw() {
+	firefly.DrawImage(s.Image, s.Point)
+}
+
+// DrawAt implements [Shape] interface.
+func (s Image) DrawAt(offset firefly.Point) {
+	firefly.DrawImage(s.Image, s.Point.Add(offset))
+}
+
+// Bounds implements [Shape] interface.
+func (s Image) Bounds() Rect {
+	return Rect{Point: s.Point, Size: s.Image.Size()}
+}
+
+// Contains implements [Shape] interface.
+//
+// Transparent pixels are considered to be inside of the image.
+func (s Image) Contains(p firefly.Point) bool {
+	return s.Bounds().Contains(p)
+}
+
+// Move the shape by the given offset.
+func (s Image) Translate(d firefly.Point) Image {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Eq reports if both shapes are the same image at the same position.
+func (s Image) Eq(other Shape) bool {
+	o, ok := other.(Image)
+	return ok && s.Point == o.Point && s.Image.Eq(o.Image)
+}
+
+// A wrapper for [firefly.DrawSubImage].
+type SubImage struct {
+	Image firefly.SubImage
+	Point firefly.Point
+}
+
+// Draw implements [Shape] interface.
+func (s SubImage) Draw() {
+	firefly.DrawSubImage(s.Image, s.Point)
+}
+
+// DrawAt implements [Shape] interface.
+func (s SubImage) DrawAt(offset firefly.Point) {
+	firefly.DrawSubImage(s.Image, s.Point.Add(offset))
+}
+
+// Bounds implements [Shape] interface.
+func (s SubImage) Bounds() Rect {
+	return Rect{Point: s.Point, Size: s.Image.Size()}
+}
+
+// Contains implements [Shape] interface.
+//
+// Transparent pixels are considered to be inside of the image.
+func (s SubImage) Contains(p firefly.Point) bool {
+	return s.Bounds().Contains(p)
+}
+
+// Move the shape by the given offset.
+func (s SubImage) Translate(d firefly.Point) SubImage {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Eq reports if both shapes are the same sub-image at the same position.
+func (s SubImage) Eq(other Shape) bool {
+	o, ok := other.(SubImage)
+	return ok && s.Point == o.Point && s.Image.Eq(o.Image)
+}
+
+// A wrapper for [firefly.Sprite.Draw].
+type Sprite struct {
+	Sprite firefly.Sprite
+	Point  firefly.Point
+}
+
+// Draw implements [Shape] interface.
+func (s Sprite) Draw() {
+	s.Sprite.Draw(s.Point)
+}
+
+// DrawAt implements [Shape] interface.
+func (s Sprite) DrawAt(offset firefly.Point) {
+	s.Sprite.Draw(s.Point.Add(offset))
+}
+
+// Bounds implements [Shape] interface.
+func (s Sprite) Bounds() Rect {
+	return Rect{Point: s.Point, Size: s.Sprite.Size()}
+}
+
+// Contains implements [Shape] interface.
+//
+// Transparent pixels are considered to be inside of the sprite.
+func (s Sprite) Contains(p firefly.Point) bool {
+	return s.Bounds().Contains(p)
+}
+
+// Move the shape by the given offset.
+func (s Sprite) Translate(d firefly.Point) Sprite {
+	s.Point = s.Point.Add(d)
+	return s
+}
@@ -0,0 +1,205 @@
+package shapes
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// A shape that can be compared to other shapes.
+//
+// Shapes stored in a [DisplayList] are compared using the == operator.
+// Shapes that are not comparable (because they contain slices)
+// must implement this interface. Otherwise, comparing them will panic.
+type Equaler interface {
+	Eq(other Shape) bool
+}
+
+// Check if both shapes are the same.
+func sameShape(a, b Shape) bool {
+	if e, ok := a.(Equaler); ok {
+		return e.Eq(b)
+	}
+	return a == b
+}
+
+type entry struct {
+	shape  Shape
+	offset firefly.Point
+}
+
+// A recorded list of draw calls.
+//
+// The list can be replayed many times and compared to other lists
+// to find what changed between frames.
+type DisplayList struct {
+	entries []entry
+}
+
+// Record the given shape.
+//
+// Groups are flattened: instead of the group itself, its visible children
+// are recorded in the drawing order. So, changes inside of a group
+// are detected when comparing lists.
+func (l *DisplayList) Add(s Shape) {
+	l.add(s, firefly.Point{})
+}
+
+func (l *DisplayList) add(s Shape, offset firefly.Point) {
+	g, ok := s.(*Group)
+	if !ok {
+		l.entries = append(l.entries, entry{shape: s, offset: offset})
+		return
+	}
+	offset = offset.Add(g.Offset)
+	for node := range g.visible {
+		l.add(node.Shape, offset)
+	}
+}
+
+// Remove all recorded shapes.
+//
+// The allocated memory is kept, so recording the next frame
+// of the same size won't cause allocations.
+func (l *DisplayList) Reset() {
+	clear(l.entries)
+	l.entries = l.entries[:0]
+}
+
+// The number of recorded shapes.
+func (l *DisplayList) Len() int {
+	return len(l.entries)
+}
+
+// Draw all the recorded shapes in the order they were recorded.
+func (l *DisplayList) Draw() {
+	for _, e := range l.entries {
+		e.shape.DrawAt(e.offset)
+	}
+}
+
+// The bounding box of all recorded shapes.
+func (l *DisplayList) Bounds() Rect {
+	var bounds Rect
+	for _, e := range l.entries {
+		bounds = bounds.Union(e.shape.Bounds().Translate(e.offset))
+	}
+	return bounds
+}
+
+// Check if both lists contain the same shapes in the same order.
+func (l *DisplayList) Eq(other *DisplayList) bool {
+	if len(l.entries) != len(other.entries) {
+		return false
+	}
+	for i, e := range l.entries {
+		if !e.same(other.entries[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get indices of shapes that are different from the ones in the other list.
+//
+// The shapes are compared by position in the list.
+// If this list is longer than the other one, all extra shapes are included.
+func (l *DisplayList) Diff(other *DisplayList) []int {
+	var changed []int
+	for i, e := range l.entries {
+		if i >= len(other.entries) || !e.same(other.entries[i]) {
+			changed = append(changed, i)
+		}
+	}
+	return changed
+}
+
+// Replace the content of the list with the content of the other list.
+func (l *DisplayList) CopyFrom(other *DisplayList) {
+	l.Reset()
+	l.entries = append(l.entries, other.entries...)
+}
+
+func (e entry) same(other entry) bool {
+	return e.offset == other.offset && sameShape(e.shape, other.shape)
+}
+
+// Recorder captures draw calls of every frame.
+//
+// Call [Recorder.Begin] at the start of every frame and then [Recorder.Add]
+// for every shape instead of drawing it directly. When all shapes are recorded,
+// draw them using either [Recorder.Draw] or [Recorder.DrawCached].
+type Recorder struct {
+	current  DisplayList
+	previous DisplayList
+	cached   DisplayList
+	valid    bool
+}
+
+// Start recording a new frame.
+//
+// The frame recorded before becomes the previous frame.
+func (r *Recorder) Begin() {
+	r.current, r.previous = r.previous, r.current
+	r.current.Reset()
+}
+
+// Record the given shape in the current frame.
+func (r *Recorder) Add(s Shape) {
+	r.current.Add(s)
+}
+
+// The shapes recorded for the current frame.
+func (r *Recorder) Current() *DisplayList {
+	return &r.current
+}
+
+// The shapes recorded for the previous frame.
+func (r *Recorder) Previous() *DisplayList {
+	return &r.previous
+}
+
+// Check if the current frame is different from the previous one.
+func (r *Recorder) Changed() bool {
+	return !r.current.Eq(&r.previous)
+}
+
+// Get indices of shapes in the current frame that changed since the previous frame.
+func (r *Recorder) Diff() []int {
+	return r.current.Diff(&r.previous)
+}
+
+// Draw all shapes recorded for the current frame.
+func (r *Recorder) Draw() {
+	r.current.Draw()
+}
+
+// Draw the current frame using the canvas as a cache.
+//
+// The shapes are rendered into the canvas only if they changed
+// since the last time the canvas was rendered. Then the canvas is drawn
+// on the screen at the given point. So, if nothing changes,
+// there is only one host call per frame.
+//
+// Before rendering, the canvas is cleared using its transparency color
+// (see [firefly.Image.SetTransparency]). If the canvas has no transparency,
+// make sure that the recorded shapes cover the whole canvas.
+func (r *Recorder) DrawCached(c firefly.Canvas, p firefly.Point) {
+	if !r.valid || !r.current.Eq(&r.cached) {
+		c.Set()
+		bg := c.Image().Transparency()
+		if bg != firefly.ColorNone {
+			firefly.ClearScreen(bg)
+		}
+		r.current.Draw()
+		firefly.UnsetCanvas()
+		r.cached.CopyFrom(&r.current)
+		r.valid = true
+	}
+	firefly.DrawImage(c.Image(), p)
+}
+
+// Force the next [Recorder.DrawCached] call to render the shapes into the canvas.
+//
+// Useful when the cache canvas is changed from the outside
+// or when the recorded shapes changed in a way that comparison cannot detect,
+// like a different image drawn on a [firefly.Canvas] used in a shape.
+func (r *Recorder) Invalidate() {
+	r.valid = false
+}
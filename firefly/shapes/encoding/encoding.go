@@ -0,0 +1,292 @@
+// Compact binary format for geometric shapes.
+//
+// Shapes can be encoded at build time, for example, by the [svg2shapes command],
+// stored in a file, and then loaded and decoded at runtime.
+//
+// [svg2shapes command]: https://pkg.go.dev/github.com/firefly-zero/firefly-go/cmd/svg2shapes
+package encoding
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+)
+
+// The first byte of the binary encoding of shapes.
+const magic = 0x53
+
+var (
+	// Returned by [Encode] for shapes that cannot be encoded,
+	// like [shapes.Text] or [shapes.Image].
+	ErrUnsupportedShape = errors.New("unsupported shape")
+
+	// Returned by [Decode] if the data is not a valid encoding of shapes.
+	ErrInvalidData = errors.New("invalid shapes data")
+)
+
+// Shape type IDs used in the binary encoding.
+const (
+	tagLine        = 1
+	tagRect        = 2
+	tagRoundedRect = 3
+	tagCircle      = 4
+	tagEllipse     = 5
+	tagTriangle    = 6
+	tagArc         = 7
+	tagSector      = 8
+	tagPolyline    = 9
+	tagPolygon     = 10
+)
+
+// Serialize the shapes into a compact binary format.
+//
+// The result can be stored in a file (at build time)
+// and then loaded and deserialized using [Decode].
+//
+// Only geometric shapes are supported: [shapes.Text], [shapes.Image], and other
+// shapes referencing assets can't be encoded. Neither can groups.
+func Encode(items []shapes.Shape) ([]byte, error) {
+	e := encoder{buf: []byte{magic}}
+	for _, s := range items {
+		err := e.shape(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e.buf, nil
+}
+
+// Deserialize shapes encoded by [Encode].
+func Decode(data []byte) ([]shapes.Shape, error) {
+	if len(data) == 0 || data[0] != magic {
+		return nil, fmt.Errorf("%w: bad magic number", ErrInvalidData)
+	}
+	d := decoder{buf: data[1:]}
+	result := make([]shapes.Shape, 0)
+	for len(d.buf) != 0 && d.err == nil {
+		s := d.shape()
+		if s != nil {
+			result = append(result, s)
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return result, nil
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) shape(s shapes.Shape) error {
+	switch s := s.(type) {
+	case shapes.Line:
+		e.tag(tagLine)
+		e.point(s.A)
+		e.point(s.B)
+		e.lineStyle(s.Style)
+	case shapes.Rect:
+		e.tag(tagRect)
+		e.point(s.Point)
+		e.size(s.Size)
+		e.style(s.Style)
+	case shapes.RoundedRect:
+		e.tag(tagRoundedRect)
+		e.point(s.Point)
+		e.size(s.Size)
+		e.size(s.Corner)
+		e.style(s.Style)
+	case shapes.Circle:
+		e.tag(tagCircle)
+		e.point(s.Point)
+		e.int(s.Diameter)
+		e.style(s.Style)
+	case shapes.Ellipse:
+		e.tag(tagEllipse)
+		e.point(s.Point)
+		e.size(s.Size)
+		e.style(s.Style)
+	case shapes.Triangle:
+		e.tag(tagTriangle)
+		e.point(s.A)
+		e.point(s.B)
+		e.point(s.C)
+		e.style(s.Style)
+	case shapes.Arc:
+		e.tag(tagArc)
+		e.point(s.Point)
+		e.int(s.Diameter)
+		e.angle(s.Start)
+		e.angle(s.Sweep)
+		e.style(s.Style)
+	case shapes.Sector:
+		e.tag(tagSector)
+		e.point(s.Point)
+		e.int(s.Diameter)
+		e.angle(s.Start)
+		e.angle(s.Sweep)
+		e.style(s.Style)
+	case shapes.Polyline:
+		e.tag(tagPolyline)
+		e.points(s.Points)
+		e.lineStyle(s.Style)
+	case shapes.Polygon:
+		e.tag(tagPolygon)
+		e.points(s.Points)
+		e.style(s.Style)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedShape, s)
+	}
+	return nil
+}
+
+func (e *encoder) tag(t byte) {
+	e.buf = append(e.buf, t)
+}
+
+func (e *encoder) int(v int) {
+	e.buf = binary.AppendVarint(e.buf, int64(v))
+}
+
+func (e *encoder) point(p firefly.Point) {
+	e.int(p.X)
+	e.int(p.Y)
+}
+
+func (e *encoder) size(s firefly.Size) {
+	e.int(s.W)
+	e.int(s.H)
+}
+
+func (e *encoder) points(points []firefly.Point) {
+	e.int(len(points))
+	for _, p := range points {
+		e.point(p)
+	}
+}
+
+func (e *encoder) angle(a firefly.Angle) {
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, math.Float32bits(a.Radians()))
+}
+
+func (e *encoder) style(s firefly.Style) {
+	e.buf = append(e.buf, byte(s.FillColor), byte(s.StrokeColor))
+	e.int(s.StrokeWidth)
+}
+
+func (e *encoder) lineStyle(s firefly.LineStyle) {
+	e.buf = append(e.buf, byte(s.Color))
+	e.int(s.Width)
+}
+
+// The decoder remembers the first error and ignores all subsequent reads.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) shape() shapes.Shape {
+	switch d.byte() {
+	case tagLine:
+		return shapes.Line{A: d.point(), B: d.point(), Style: d.lineStyle()}
+	case tagRect:
+		return shapes.Rect{Point: d.point(), Size: d.size(), Style: d.style()}
+	case tagRoundedRect:
+		return shapes.RoundedRect{Point: d.point(), Size: d.size(), Corner: d.size(), Style: d.style()}
+	case tagCircle:
+		return shapes.Circle{Point: d.point(), Diameter: d.int(), Style: d.style()}
+	case tagEllipse:
+		return shapes.Ellipse{Point: d.point(), Size: d.size(), Style: d.style()}
+	case tagTriangle:
+		return shapes.Triangle{A: d.point(), B: d.point(), C: d.point(), Style: d.style()}
+	case tagArc:
+		return shapes.Arc{Point: d.point(), Diameter: d.int(), Start: d.angle(), Sweep: d.angle(), Style: d.style()}
+	case tagSector:
+		return shapes.Sector{Point: d.point(), Diameter: d.int(), Start: d.angle(), Sweep: d.angle(), Style: d.style()}
+	case tagPolyline:
+		return shapes.Polyline{Points: d.points(), Style: d.lineStyle()}
+	case tagPolygon:
+		return shapes.Polygon{Points: d.points(), Style: d.style()}
+	default:
+		d.fail("unknown shape type")
+		return nil
+	}
+}
+
+func (d *decoder) fail(reason string) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s", ErrInvalidData, reason)
+	}
+	d.buf = nil
+}
+
+func (d *decoder) byte() byte {
+	if len(d.buf) == 0 {
+		d.fail("unexpected end of data")
+		return 0
+	}
+	b := d.buf[0]
+	d.buf = d.buf[1:]
+	return b
+}
+
+func (d *decoder) int() int {
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.fail("bad varint")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return int(v)
+}
+
+func (d *decoder) point() firefly.Point {
+	return firefly.P(d.int(), d.int())
+}
+
+func (d *decoder) size() firefly.Size {
+	return firefly.S(d.int(), d.int())
+}
+
+func (d *decoder) points() []firefly.Point {
+	n := d.int()
+	if n < 0 || n > len(d.buf) {
+		d.fail("bad number of points")
+		return nil
+	}
+	points := make([]firefly.Point, n)
+	for i := range points {
+		points[i] = d.point()
+	}
+	return points
+}
+
+func (d *decoder) angle() firefly.Angle {
+	if len(d.buf) < 4 {
+		d.fail("unexpected end of data")
+		return firefly.Angle{}
+	}
+	bits := binary.LittleEndian.Uint32(d.buf)
+	d.buf = d.buf[4:]
+	return firefly.Radians(math.Float32frombits(bits))
+}
+
+func (d *decoder) style() firefly.Style {
+	return firefly.Style{
+		FillColor:   firefly.Color(d.byte()),
+		StrokeColor: firefly.Color(d.byte()),
+		StrokeWidth: d.int(),
+	}
+}
+
+func (d *decoder) lineStyle() firefly.LineStyle {
+	return firefly.LineStyle{
+		Color: firefly.Color(d.byte()),
+		Width: d.int(),
+	}
+}
@@ -0,0 +1,56 @@
+package encoding_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+	"github.com/firefly-zero/firefly-go/firefly/shapes/encoding"
+)
+
+func TestEncode_Roundtrip(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	S := firefly.S
+	style := firefly.Style{FillColor: firefly.ColorRed, StrokeColor: firefly.ColorBlue, StrokeWidth: 2}
+	given := []shapes.Shape{
+		shapes.Line{A: P(-1, 2), B: P(300, -400), Style: firefly.L(firefly.ColorGray, 3)},
+		shapes.Rect{Point: P(1, 2), Size: S(3, 4), Style: style},
+		shapes.RoundedRect{Point: P(1, 2), Size: S(3, 4), Corner: S(1, 1), Style: style},
+		shapes.Circle{Point: P(1, 2), Diameter: 10, Style: style},
+		shapes.Ellipse{Point: P(1, 2), Size: S(3, 4), Style: style},
+		shapes.Triangle{A: P(1, 2), B: P(3, 4), C: P(5, 6), Style: style},
+		shapes.Arc{Point: P(1, 2), Diameter: 10, Start: firefly.Degrees(45), Sweep: firefly.Degrees(90), Style: style},
+		shapes.Sector{Point: P(1, 2), Diameter: 10, Start: firefly.Degrees(45), Sweep: firefly.Degrees(90), Style: style},
+		shapes.Polyline{Points: []firefly.Point{P(1, 2), P(3, 4)}, Style: firefly.L(firefly.ColorGray, 1)},
+		shapes.Polygon{Points: []firefly.Point{P(1, 2), P(3, 4), P(0, 5)}, Style: style},
+	}
+	data, err := encoding.Encode(given)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got, err := encoding.Decode(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != len(given) {
+		t.Fatalf("want %d shapes, got %d", len(given), len(got))
+	}
+	for i, s := range given {
+		same := false
+		if e, ok := s.(shapes.Equaler); ok {
+			same = e.Eq(got[i])
+		} else {
+			same = s == got[i]
+		}
+		if !same {
+			t.Errorf("shape #%d: want %v, got %v", i, s, got[i])
+		}
+	}
+
+	_, err = encoding.Decode(data[:len(data)-3])
+	if !errors.Is(err, encoding.ErrInvalidData) {
+		t.Errorf("truncated data: want ErrInvalidData, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+// Make a bounding box covering all the given points.
+func boundsOf(points ...firefly.Point) Rect {
+	if len(points) == 0 {
+		return Rect{}
+	}
+	low := points[0]
+	high := points[0]
+	for _, p := range points[1:] {
+		low = low.ComponentMin(p)
+		high = high.ComponentMax(p)
+	}
+	size := high.Sub(low).Add(firefly.P(1, 1))
+	return Rect{Point: low, Size: size.Size()}
+}
+
+// Multiply the integer by the given factor and round the result.
+func scaleInt(v int, k float32) int {
+	return int(tinymath.Round(float32(v) * k))
+}
+
+// Move the point closer to or further from the pivot.
+func scalePoint(p, pivot firefly.Point, k float32) firefly.Point {
+	d := p.Sub(pivot)
+	return pivot.Add(firefly.P(scaleInt(d.X, k), scaleInt(d.Y, k)))
+}
+
+// Rotate the point clockwise around the pivot.
+func rotatePoint(p, pivot firefly.Point, a firefly.Angle) firefly.Point {
+	sin, cos := tinymath.SinCos(a.Radians())
+	dx := float32(p.X - pivot.X)
+	dy := float32(p.Y - pivot.Y)
+	x := dx*cos - dy*sin
+	y := dx*sin + dy*cos
+	return pivot.Add(firefly.P(
+		int(tinymath.Round(x)),
+		int(tinymath.Round(y)),
+	))
+}
+
+// Get the offset of the pixel center relative to the given center.
+//
+// The center is given in doubled coordinates to avoid rounding
+// for shapes with an odd diameter.
+func offsetFrom(p firefly.Point, cx2, cy2 int) (float32, float32) {
+	dx := float32(p.X*2+1-cx2) / 2
+	dy := float32(p.Y*2+1-cy2) / 2
+	return dx, dy
+}
+
+// Check if the angle of the vector (dx, dy) is within the given sweep.
+//
+// The angle is measured clockwise (because Y goes down on the screen)
+// starting from the positive X axis.
+func inSweep(dx, dy float32, start, sweep firefly.Angle) bool {
+	if tinymath.Abs(sweep.Radians()) >= 2*math.Pi {
+		return true
+	}
+	angle := firefly.Radians(math.Pi / 2 * tinymath.Atan2Norm(dy, dx))
+	if sweep.Radians() < 0 {
+		diff := start.Sub(angle).Normalize()
+		return diff.Radians() <= -sweep.Radians()
+	}
+	diff := angle.Sub(start).Normalize()
+	return diff.Radians() <= sweep.Radians()
+}
+
+// The points of the arc that define its bounding box.
+//
+// It's both ends of the arc and all points on the circle
+// at which the arc crosses the X or Y axis.
+func arcPoints(center firefly.Point, d int, start, sweep firefly.Angle) []firefly.Point {
+	r := float32(d) / 2
+	point := func(a float32) firefly.Point {
+		sin, cos := tinymath.SinCos(a)
+		return center.Add(firefly.P(
+			int(tinymath.Round(cos*r)),
+			int(tinymath.Round(sin*r)),
+		))
+	}
+	from := start.Radians()
+	to := from + sweep.Radians()
+	if to < from {
+		from, to = to, from
+	}
+	points := []firefly.Point{point(from), point(to)}
+	const quarter = math.Pi / 2
+	for a := tinymath.Ceil(from/quarter) * quarter; a < to; a += quarter {
+		points = append(points, point(a))
+	}
+	return points
+}
+
+// Get the squared distance from the point to the line segment.
+func segmentDistSquared(p, a, b firefly.Point) float32 {
+	px := float32(p.X - a.X)
+	py := float32(p.Y - a.Y)
+	dx := float32(b.X - a.X)
+	dy := float32(b.Y - a.Y)
+	lenSq := dx*dx + dy*dy
+	t := float32(0)
+	if lenSq != 0 {
+		t = tinymath.Clamp((px*dx+py*dy)/lenSq, 0, 1)
+	}
+	ex := px - t*dx
+	ey := py - t*dy
+	return ex*ex + ey*ey
+}
+
+// Get the doubled signed area of the triangle.
+//
+// The sign indicates the winding direction of the points.
+func cross(a, b, c firefly.Point) int {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
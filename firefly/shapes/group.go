@@ -0,0 +1,171 @@
+package shapes
+
+import (
+	"slices"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+var _ Shape = &Group{}
+
+// A child of a [Group]. Constructed by [Group.Add].
+type Node struct {
+	// The shape to draw.
+	Shape Shape
+
+	// If true, the node is not drawn and ignored by hit-testing.
+	Hidden bool
+
+	group *Group
+	z     int
+	// The order in which the node was added into the group.
+	seq int
+}
+
+// The drawing order of the node. See [Node.SetZ].
+func (n *Node) Z() int {
+	return n.z
+}
+
+// Set the drawing order of the node.
+//
+// Nodes with a higher Z are drawn on top of nodes with a lower Z.
+// Nodes with the same Z are drawn in the order they were added.
+func (n *Node) SetZ(z int) {
+	if n.z == z {
+		return
+	}
+	g := n.group
+	if g == nil {
+		n.z = z
+		return
+	}
+	g.Remove(n)
+	n.z = z
+	n.group = g
+	g.insert(n)
+}
+
+// A collection of shapes, sprites, and text that are drawn together.
+//
+// A group is a [Shape] itself, so groups can be nested.
+type Group struct {
+	// The offset applied to all children when drawing and hit-testing.
+	Offset firefly.Point
+
+	// If true, no children are drawn.
+	Hidden bool
+
+	// Sorted in the drawing order.
+	nodes []*Node
+	seq   int
+}
+
+// Add a new child to the group.
+//
+// The returned [Node] can be used to change the child z-order and visibility
+// or to replace the shape.
+func (g *Group) Add(s Shape) *Node {
+	node := &Node{Shape: s, seq: g.seq}
+	g.seq++
+	g.insert(node)
+	return node
+}
+
+// Insert the node keeping the nodes sorted in the drawing order.
+func (g *Group) insert(node *Node) {
+	node.group = g
+	i, _ := slices.BinarySearchFunc(g.nodes, node, func(a, b *Node) int {
+		if a.z != b.z {
+			return a.z - b.z
+		}
+		return a.seq - b.seq
+	})
+	g.nodes = slices.Insert(g.nodes, i, node)
+}
+
+// Remove the given child from the group.
+func (g *Group) Remove(node *Node) {
+	if node.group != g {
+		return
+	}
+	node.group = nil
+	g.nodes = slices.DeleteFunc(g.nodes, func(n *Node) bool {
+		return n == node
+	})
+}
+
+// Remove all children from the group.
+func (g *Group) Clear() {
+	for _, node := range g.nodes {
+		node.group = nil
+	}
+	clear(g.nodes)
+	g.nodes = g.nodes[:0]
+}
+
+// The number of children in the group.
+func (g *Group) Len() int {
+	return len(g.nodes)
+}
+
+// Iterate over all visible children in the order they are drawn.
+func (g *Group) visible(yield func(*Node) bool) {
+	if g.Hidden {
+		return
+	}
+	for _, node := range g.nodes {
+		if node.Hidden {
+			continue
+		}
+		if !yield(node) {
+			return
+		}
+	}
+}
+
+// Draw implements [Shape] interface.
+func (g *Group) Draw() {
+	g.DrawAt(firefly.Point{})
+}
+
+// DrawAt implements [Shape] interface.
+func (g *Group) DrawAt(offset firefly.Point) {
+	offset = offset.Add(g.Offset)
+	for node := range g.visible {
+		node.Shape.DrawAt(offset)
+	}
+}
+
+// Bounds implements [Shape] interface.
+//
+// It is the union of bounding boxes of all visible children.
+func (g *Group) Bounds() Rect {
+	var bounds Rect
+	for node := range g.visible {
+		bounds = bounds.Union(node.Shape.Bounds())
+	}
+	return bounds.Translate(g.Offset)
+}
+
+// Contains implements [Shape] interface.
+func (g *Group) Contains(p firefly.Point) bool {
+	return g.HitTest(p) != nil
+}
+
+// Find the topmost visible child containing the given point.
+//
+// Returns nil if there is no such child.
+func (g *Group) HitTest(p firefly.Point) *Node {
+	if g.Hidden {
+		return nil
+	}
+	p = p.Sub(g.Offset)
+	var hit *Node
+	for node := range g.visible {
+		if node.Shape.Contains(p) {
+			hit = node
+		}
+	}
+	return hit
+}
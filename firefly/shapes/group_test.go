@@ -0,0 +1,88 @@
+package shapes_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+)
+
+func TestGroup_HitTest(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	S := firefly.S
+	g := shapes.Group{Offset: P(100, 100)}
+	back := g.Add(shapes.Rect{Point: P(0, 0), Size: S(20, 20)})
+	front := g.Add(shapes.Rect{Point: P(10, 10), Size: S(20, 20)})
+	front.SetZ(1)
+	back.SetZ(2)
+
+	if got := g.HitTest(P(115, 115)); got != back {
+		t.Errorf("want the node with the highest Z, got %v", got)
+	}
+	if got := g.HitTest(P(125, 125)); got != front {
+		t.Errorf("want the only node under point, got %v", got)
+	}
+	back.Hidden = true
+	if got := g.HitTest(P(115, 115)); got != front {
+		t.Errorf("hidden nodes must be ignored, got %v", got)
+	}
+	if g.Contains(P(5, 5)) {
+		t.Error("the offset must be applied")
+	}
+
+	want := shapes.Rect{Point: P(110, 110), Size: S(20, 20)}
+	if got := g.Bounds(); got != want {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	back.Hidden = false
+	back.SetZ(0)
+	if got := g.HitTest(P(115, 115)); got != front {
+		t.Errorf("changing Z must change the order, got %v", got)
+	}
+	front.SetZ(0)
+	if got := g.HitTest(P(115, 115)); got != front {
+		t.Errorf("nodes with the same Z must keep the order they were added, got %v", got)
+	}
+}
+
+func TestDisplayList_Diff(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	S := firefly.S
+	var r shapes.Recorder
+
+	g := &shapes.Group{}
+	g.Add(shapes.Rect{Point: P(0, 0), Size: S(20, 20)})
+	circle := g.Add(shapes.Circle{Point: P(1, 1), Diameter: 4})
+
+	r.Begin()
+	r.Add(g)
+	r.Add(shapes.Line{A: P(1, 1), B: P(2, 2)})
+	if !r.Changed() {
+		t.Error("the first frame must be different from an empty frame")
+	}
+	if got := r.Current().Len(); got != 3 {
+		t.Errorf("groups must be flattened, got %d shapes", got)
+	}
+
+	r.Begin()
+	r.Add(g)
+	r.Add(shapes.Line{A: P(1, 1), B: P(2, 2)})
+	if r.Changed() {
+		t.Error("the same shapes must not be reported as changed")
+	}
+
+	circle.Shape = shapes.Circle{Point: P(2, 2), Diameter: 4}
+	r.Begin()
+	r.Add(g)
+	r.Add(shapes.Line{A: P(1, 1), B: P(2, 2)})
+	r.Add(shapes.Line{A: P(3, 3), B: P(4, 4)})
+	got := r.Diff()
+	want := []int{1, 3}
+	if !slices.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,332 @@
+package shapes
+
+import (
+	"math/bits"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// The size of the [Pattern] tile.
+const patternSize = 8
+
+// A two-color 8x8 pattern used to fill shapes instead of a solid color.
+//
+// With only 16 colors in the palette, patterns are the main way
+// to get more shades: mixing two colors in a fine pattern ("dithering")
+// makes them look like a single color in between.
+//
+// Patterns are aligned to the screen coordinates (and not to the shape),
+// so neighboring shapes with the same pattern seamlessly join.
+type Pattern struct {
+	// Each bit represents one pixel: 1 is Fg and 0 is Bg.
+	//
+	// The bit x of the byte y is the pixel at (x, y).
+	Mask [patternSize]uint8
+
+	// The color used for set bits of the mask.
+	Fg firefly.Color
+
+	// The color used for unset bits of the mask.
+	//
+	// If [firefly.ColorNone], these pixels are transparent.
+	Bg firefly.Color
+}
+
+// The 8x8 Bayer matrix for ordered dithering.
+var bayer = [patternSize][patternSize]uint8{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// The number of distinct levels of [Bayer] dithering (from 0 to 64 included).
+const BayerLevels = patternSize*patternSize + 1
+
+// Ordered dithering between two colors.
+//
+// The level is the number of pixels (out of 64) that have the second color.
+// So, 0 is solid a, 32 is an even mix (checkerboard), and 64 is solid b.
+func Bayer(a, b firefly.Color, level int) Pattern {
+	p := Pattern{Fg: b, Bg: a}
+	for y, row := range bayer {
+		for x, threshold := range row {
+			if int(threshold) < level {
+				p.Mask[y] |= 1 << x
+			}
+		}
+	}
+	return p
+}
+
+// Checkerboard pattern of 1x1 cells.
+func Checker(a, b firefly.Color) Pattern {
+	return Mask4x4(a, b, 0b_1010_0101_1010_0101)
+}
+
+// Horizontal stripes of the given width (in pixels).
+//
+// The width must be 1, 2, or 4 for the pattern to tile seamlessly.
+func HStripes(a, b firefly.Color, width int) Pattern {
+	p := Pattern{Fg: b, Bg: a}
+	for y := range p.Mask {
+		if (y/max(width, 1))%2 == 1 {
+			p.Mask[y] = 0xff
+		}
+	}
+	return p
+}
+
+// Vertical stripes of the given width (in pixels).
+//
+// The width must be 1, 2, or 4 for the pattern to tile seamlessly.
+func VStripes(a, b firefly.Color, width int) Pattern {
+	var row uint8
+	for x := range patternSize {
+		if (x/max(width, 1))%2 == 1 {
+			row |= 1 << x
+		}
+	}
+	p := Pattern{Fg: b, Bg: a}
+	for y := range p.Mask {
+		p.Mask[y] = row
+	}
+	return p
+}
+
+// Pattern from a custom 4x4 bitmask.
+//
+// The bit y*4+x is the pixel at (x, y). Set bits have the color b.
+func Mask4x4(a, b firefly.Color, mask uint16) Pattern {
+	p := Pattern{Fg: b, Bg: a}
+	for y := range p.Mask {
+		row := uint8(mask>>((y%4)*4)) & 0b1111
+		p.Mask[y] = row | row<<4
+	}
+	return p
+}
+
+// Pattern from a custom 8x8 bitmask.
+//
+// The bit y*8+x is the pixel at (x, y). Set bits have the color b.
+func Mask8x8(a, b firefly.Color, mask uint64) Pattern {
+	p := Pattern{Fg: b, Bg: a}
+	for y := range p.Mask {
+		p.Mask[y] = uint8(mask >> (y * 8))
+	}
+	return p
+}
+
+// Get the color of the pattern at the given point.
+func (p Pattern) At(point firefly.Point) firefly.Color {
+	x := uint(point.X) % patternSize
+	y := uint(point.Y) % patternSize
+	if p.Mask[y]>>x&1 != 0 {
+		return p.Fg
+	}
+	return p.Bg
+}
+
+// Shift the pattern by the given offset.
+//
+// The pixel that was at (0, 0) is moved to the given point.
+func (p Pattern) Offset(d firefly.Point) Pattern {
+	dx := ((d.X % patternSize) + patternSize) % patternSize
+	dy := ((d.Y % patternSize) + patternSize) % patternSize
+	var res Pattern
+	res.Fg = p.Fg
+	res.Bg = p.Bg
+	for y, row := range p.Mask {
+		res.Mask[(y+dy)%patternSize] = bits.RotateLeft8(row, dx)
+	}
+	return res
+}
+
+// How many tiles are cached before the cache is cleared.
+//
+// Animated patterns and gradients produce a new pattern for every offset,
+// so without a limit the cache would grow forever.
+const maxTiles = 256
+
+// Cached tiles for patterns.
+var tiles = make(map[Pattern]firefly.Canvas)
+
+// Get an 8x8 canvas with the pattern.
+//
+// The canvas is cached, so it's created only once for every distinct pattern
+// unless many different patterns are used. Don't modify the returned canvas.
+func (p Pattern) Tile() firefly.Canvas {
+	tile, ok := tiles[p]
+	if ok {
+		return tile
+	}
+	if len(tiles) >= maxTiles {
+		clear(tiles)
+	}
+	tile = firefly.NewCanvas(firefly.S(patternSize, patternSize))
+	if p.Fg == firefly.ColorNone || p.Bg == firefly.ColorNone {
+		key := unusedColor(p.Fg, p.Bg)
+		tile.Image().SetTransparency(key)
+		tile.Fill(key)
+	}
+	for y := range patternSize {
+		for x := range patternSize {
+			point := firefly.P(x, y)
+			tile.SetPixel(point, p.At(point))
+		}
+	}
+	tiles[p] = tile
+	return tile
+}
+
+// Find a color that is neither a nor b.
+//
+// Used as the transparency color for canvases with patterns.
+func unusedColor(a, b firefly.Color) firefly.Color {
+	for c := firefly.ColorBlack; c <= firefly.ColorDarkGray; c++ {
+		if c != a && c != b {
+			return c
+		}
+	}
+	return firefly.ColorNone
+}
+
+// Fill the rectangle with the pattern.
+//
+// Uses a single host call ([firefly.DrawSubTile]) with the cached [Pattern.Tile].
+func FillRect(p firefly.Point, s firefly.Size, pat Pattern) {
+	tile := pat.Offset(firefly.P(-p.X, -p.Y)).Tile()
+	sub := tile.Image().Sub(firefly.Point{}, firefly.S(patternSize, patternSize))
+	firefly.DrawSubTile(sub, p, s)
+}
+
+// Fill the rectangle with a vertical dithered gradient.
+//
+// The top edge has the color a and the bottom edge has the color b.
+// Uses one host call for every distinct [Bayer] level.
+func DrawVGradient(p firefly.Point, s firefly.Size, a, b firefly.Color) {
+	drawGradient(p, s, a, b, true)
+}
+
+// Fill the rectangle with a horizontal dithered gradient.
+//
+// The left edge has the color a and the right edge has the color b.
+// Uses one host call for every distinct [Bayer] level.
+func DrawHGradient(p firefly.Point, s firefly.Size, a, b firefly.Color) {
+	drawGradient(p, s, a, b, false)
+}
+
+func drawGradient(p firefly.Point, s firefly.Size, a, b firefly.Color, vertical bool) {
+	length := s.W
+	if vertical {
+		length = s.H
+	}
+	if length <= 0 {
+		return
+	}
+	// Split the gradient into bands, one band per Bayer level.
+	start := 0
+	for start < length {
+		level := gradientLevel(start, length)
+		end := start + 1
+		for end < length && gradientLevel(end, length) == level {
+			end++
+		}
+		if vertical {
+			FillRect(firefly.P(p.X, p.Y+start), firefly.S(s.W, end-start), Bayer(a, b, level))
+		} else {
+			FillRect(firefly.P(p.X+start, p.Y), firefly.S(end-start, s.H), Bayer(a, b, level))
+		}
+		start = end
+	}
+}
+
+// The Bayer level for the given position in the gradient.
+func gradientLevel(pos, length int) int {
+	if length == 1 {
+		return 0
+	}
+	return pos * (BayerLevels - 1) / (length - 1)
+}
+
+var _ Shape = &PatternFill{}
+
+// A shape filled with a [Pattern] instead of a solid color.
+//
+// The style of the shape is ignored: all pixels for which [Shape.Contains]
+// returns true are filled with the pattern. If you need an outline,
+// draw an outlined shape on top.
+//
+// The pattern is aligned to the shape: when drawn with an offset
+// (for example, in a moving [Group]), the pattern moves together with the shape.
+//
+// Constructed by [NewPatternFill].
+type PatternFill struct {
+	shape   Shape
+	pattern Pattern
+	bounds  Rect
+	canvas  firefly.Canvas
+}
+
+// Rasterize the shape filled with the pattern.
+//
+// The rasterization happens on the Go side, once. The result is cached
+// in a canvas and drawn with a single host call.
+// Rectangles aren't rasterized at all, they are drawn using [FillRect].
+func NewPatternFill(s Shape, p Pattern) *PatternFill {
+	f := &PatternFill{shape: s, pattern: p, bounds: s.Bounds()}
+	if _, isRect := s.(Rect); isRect {
+		return f
+	}
+	size := f.bounds.Size
+	if size.W <= 0 || size.H <= 0 {
+		return f
+	}
+	key := unusedColor(p.Fg, p.Bg)
+	f.canvas = firefly.NewCanvas(size)
+	f.canvas.Image().SetTransparency(key)
+	f.canvas.Fill(key)
+	for y := range size.H {
+		for x := range size.W {
+			local := firefly.P(x, y)
+			point := local.Add(f.bounds.Point)
+			if s.Contains(point) {
+				f.canvas.SetPixel(local, p.At(point))
+			}
+		}
+	}
+	return f
+}
+
+// Draw implements [Shape] interface.
+func (f *PatternFill) Draw() {
+	f.DrawAt(firefly.Point{})
+}
+
+// DrawAt implements [Shape] interface.
+func (f *PatternFill) DrawAt(offset firefly.Point) {
+	point := f.bounds.Point.Add(offset)
+	if _, isRect := f.shape.(Rect); isRect {
+		// Shift the pattern so that it's aligned the same way as in the canvas.
+		FillRect(point, f.bounds.Size, f.pattern.Offset(offset))
+		return
+	}
+	if f.canvas.Size().W == 0 {
+		return
+	}
+	firefly.DrawImage(f.canvas.Image(), point)
+}
+
+// Bounds implements [Shape] interface.
+func (f *PatternFill) Bounds() Rect {
+	return f.bounds
+}
+
+// Contains implements [Shape] interface.
+func (f *PatternFill) Contains(p firefly.Point) bool {
+	return f.shape.Contains(p)
+}
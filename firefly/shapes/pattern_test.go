@@ -0,0 +1,84 @@
+package shapes_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+)
+
+// Count how many pixels of the 8x8 tile have the color.
+func countColor(p shapes.Pattern, c firefly.Color) int {
+	n := 0
+	for y := range 8 {
+		for x := range 8 {
+			if p.At(firefly.P(x, y)) == c {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestBayer(t *testing.T) {
+	t.Parallel()
+	a := firefly.ColorBlack
+	b := firefly.ColorWhite
+	for level := range shapes.BayerLevels {
+		p := shapes.Bayer(a, b, level)
+		got := countColor(p, b)
+		if got != level {
+			t.Errorf("level %d: want %d pixels, but got %d", level, level, got)
+		}
+	}
+	if shapes.Bayer(a, b, 32) != shapes.Checker(a, b) {
+		t.Errorf("level 32 must be a checkerboard")
+	}
+}
+
+func TestPattern_At(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	a := firefly.ColorBlack
+	b := firefly.ColorWhite
+	tests := []struct {
+		name    string
+		pattern shapes.Pattern
+		point   firefly.Point
+		want    firefly.Color
+	}{
+		{name: "checker origin", pattern: shapes.Checker(a, b), point: P(0, 0), want: b},
+		{name: "checker next", pattern: shapes.Checker(a, b), point: P(1, 0), want: a},
+		{name: "checker negative", pattern: shapes.Checker(a, b), point: P(-1, -1), want: b},
+		{name: "hstripes", pattern: shapes.HStripes(a, b, 2), point: P(5, 2), want: b},
+		{name: "vstripes", pattern: shapes.VStripes(a, b, 2), point: P(5, 2), want: a},
+		{name: "mask4x4 wraps", pattern: shapes.Mask4x4(a, b, 0b10), point: P(5, 4), want: b},
+		{name: "mask8x8", pattern: shapes.Mask8x8(a, b, 1<<10), point: P(2, 1), want: b},
+		{name: "offset", pattern: shapes.Mask8x8(a, b, 1).Offset(P(3, -1)), point: P(3, 7), want: b},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := test.pattern.At(test.point)
+			if got != test.want {
+				t.Errorf("point: {%d, %d}, want %s, but got %s", test.point.X, test.point.Y, test.want, got)
+			}
+		})
+	}
+}
+
+func TestPattern_Tile(t *testing.T) { //nolint:paralleltest // the tile cache is not thread-safe
+	// More distinct patterns than the cache holds.
+	for level := range shapes.BayerLevels {
+		for dx := range 8 {
+			p := shapes.Bayer(firefly.ColorBlack, firefly.ColorWhite, level).Offset(firefly.P(dx, 0))
+			tile := p.Tile()
+			for _, point := range []firefly.Point{firefly.P(0, 0), firefly.P(3, 5), firefly.P(7, 7)} {
+				if got := tile.GetPixel(point); got != p.At(point) {
+					t.Fatalf("level %d, dx %d, %v: want %s, got %s", level, dx, point, p.At(point), got)
+				}
+			}
+		}
+	}
+}
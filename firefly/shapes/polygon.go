@@ -0,0 +1,229 @@
+package shapes
+
+import (
+	"slices"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+var (
+	_ Shape = Polyline{}
+	_ Shape = Polygon{}
+)
+
+// A sequence of connected lines.
+//
+// Drawn as multiple [firefly.DrawLine] calls.
+type Polyline struct {
+	Points []firefly.Point
+	Style  firefly.LineStyle
+}
+
+// Draw implements [Shape] interface.
+func (s Polyline) Draw() {
+	s.DrawAt(firefly.Point{})
+}
+
+// DrawAt implements [Shape] interface.
+func (s Polyline) DrawAt(offset firefly.Point) {
+	for i := 1; i < len(s.Points); i++ {
+		a := s.Points[i-1].Add(offset)
+		b := s.Points[i].Add(offset)
+		firefly.DrawLine(a, b, s.Style)
+	}
+}
+
+// Bounds implements [Shape] interface.
+func (s Polyline) Bounds() Rect {
+	return Line{Style: s.Style}.pad(boundsOf(s.Points...))
+}
+
+// Contains implements [Shape] interface.
+//
+// The point is considered to be on the polyline if it's covered by any of the lines.
+func (s Polyline) Contains(p firefly.Point) bool {
+	for i := 1; i < len(s.Points); i++ {
+		line := Line{A: s.Points[i-1], B: s.Points[i], Style: s.Style}
+		if line.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Eq reports if both shapes have the same points and style.
+func (s Polyline) Eq(other Shape) bool {
+	o, ok := other.(Polyline)
+	return ok && s.Style == o.Style && slices.Equal(s.Points, o.Points)
+}
+
+// Move the shape by the given offset.
+//
+// The points are copied, the original shape is not modified.
+func (s Polyline) Translate(d firefly.Point) Polyline {
+	s.Points = mapPoints(s.Points, func(p firefly.Point) firefly.Point {
+		return p.Add(d)
+	})
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+//
+// The points are copied, the original shape is not modified.
+func (s Polyline) Scale(pivot firefly.Point, k float32) Polyline {
+	s.Points = mapPoints(s.Points, func(p firefly.Point) firefly.Point {
+		return scalePoint(p, pivot, k)
+	})
+	return s
+}
+
+// Rotate the shape clockwise around the given pivot point.
+//
+// The points are copied, the original shape is not modified.
+func (s Polyline) Rotate(pivot firefly.Point, a firefly.Angle) Polyline {
+	s.Points = mapPoints(s.Points, func(p firefly.Point) firefly.Point {
+		return rotatePoint(p, pivot, a)
+	})
+	return s
+}
+
+// A closed shape with any number of vertices.
+//
+// Unlike [Triangle], there is no dedicated host function for drawing polygons.
+// So, the fill is drawn as one horizontal line per row and the stroke
+// is drawn as one line per edge. Self-intersecting polygons
+// are filled using the even-odd rule.
+type Polygon struct {
+	Points []firefly.Point
+	Style  firefly.Style
+}
+
+// Draw implements [Shape] interface.
+func (s Polygon) Draw() {
+	s.DrawAt(firefly.Point{})
+}
+
+// DrawAt implements [Shape] interface.
+func (s Polygon) DrawAt(offset firefly.Point) {
+	if len(s.Points) == 0 {
+		return
+	}
+	if s.Style.FillColor != firefly.ColorNone {
+		fill := firefly.L(s.Style.FillColor, 1)
+		s.Spans(func(y, x1, x2 int) {
+			a := firefly.P(x1, y).Add(offset)
+			b := firefly.P(x2, y).Add(offset)
+			firefly.DrawLine(a, b, fill)
+		})
+	}
+	if s.Style.StrokeWidth > 0 {
+		stroke := s.Style.LineStyle()
+		prev := s.Points[len(s.Points)-1]
+		for _, p := range s.Points {
+			firefly.DrawLine(prev.Add(offset), p.Add(offset), stroke)
+			prev = p
+		}
+	}
+}
+
+// Spans calls the callback for every horizontal span of pixels inside of the polygon.
+//
+// Both ends of the span (x1 and x2) are included.
+// Can be used to rasterize the polygon with a custom fill.
+func (s Polygon) Spans(f func(y, x1, x2 int)) {
+	bounds := s.Bounds()
+	xs := make([]float32, 0, len(s.Points))
+	for y := bounds.Point.Y; y < bounds.Point.Y+bounds.Size.H; y++ {
+		xs = s.crossings(xs[:0], float32(y)+.5)
+		slices.Sort(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			x1 := int(tinymath.Ceil(xs[i] - .5))
+			x2 := int(tinymath.Floor(xs[i+1] - .5))
+			if x1 <= x2 {
+				f(y, x1, x2)
+			}
+		}
+	}
+}
+
+// Find X coordinates at which the horizontal line crosses the polygon edges.
+func (s Polygon) crossings(xs []float32, y float32) []float32 {
+	prev := s.Points[len(s.Points)-1]
+	for _, p := range s.Points {
+		ay := float32(prev.Y) + .5
+		by := float32(p.Y) + .5
+		if (ay <= y) != (by <= y) {
+			ax := float32(prev.X) + .5
+			bx := float32(p.X) + .5
+			xs = append(xs, ax+(y-ay)*(bx-ax)/(by-ay))
+		}
+		prev = p
+	}
+	return xs
+}
+
+// Bounds implements [Shape] interface.
+func (s Polygon) Bounds() Rect {
+	return boundsOf(s.Points...)
+}
+
+// Contains implements [Shape] interface.
+func (s Polygon) Contains(p firefly.Point) bool {
+	if len(s.Points) == 0 {
+		return false
+	}
+	x := float32(p.X) + .5
+	inside := false
+	for _, cx := range s.crossings(nil, float32(p.Y)+.5) {
+		if cx > x {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Eq reports if both shapes have the same points and style.
+func (s Polygon) Eq(other Shape) bool {
+	o, ok := other.(Polygon)
+	return ok && s.Style == o.Style && slices.Equal(s.Points, o.Points)
+}
+
+// Move the shape by the given offset.
+//
+// The points are copied, the original shape is not modified.
+func (s Polygon) Translate(d firefly.Point) Polygon {
+	s.Points = mapPoints(s.Points, func(p firefly.Point) firefly.Point {
+		return p.Add(d)
+	})
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+//
+// The points are copied, the original shape is not modified.
+func (s Polygon) Scale(pivot firefly.Point, k float32) Polygon {
+	s.Points = mapPoints(s.Points, func(p firefly.Point) firefly.Point {
+		return scalePoint(p, pivot, k)
+	})
+	return s
+}
+
+// Rotate the shape clockwise around the given pivot point.
+//
+// The points are copied, the original shape is not modified.
+func (s Polygon) Rotate(pivot firefly.Point, a firefly.Angle) Polygon {
+	s.Points = mapPoints(s.Points, func(p firefly.Point) firefly.Point {
+		return rotatePoint(p, pivot, a)
+	})
+	return s
+}
+
+// Apply the function to every point and return the new slice.
+func mapPoints(points []firefly.Point, f func(firefly.Point) firefly.Point) []firefly.Point {
+	res := make([]firefly.Point, len(points))
+	for i, p := range points {
+		res[i] = f(p)
+	}
+	return res
+}
@@ -3,14 +3,54 @@
 // The main firefly module provides functions for drawing shapes.
 // This modules provides useful struct for when you need to store
 // or manipulate a shape before it can be drawn.
+//
+// All shapes can report their bounding box and check if they contain a point,
+// which makes them usable as hit regions for touch and cursor input.
+// Transformations (Translate, Scale, Rotate) don't modify the shape
+// but return a new one. The stroke width is never scaled.
+//
+// Angles are measured clockwise starting from the positive X axis
+// because the Y axis on the screen points down.
+//
+// To work around the 16-color palette, shapes can be filled with
+// a dithered [Pattern] using [NewPatternFill], and rectangles
+// can be filled with a gradient using [DrawVGradient] and [DrawHGradient].
 package shapes
 
-import "github.com/firefly-zero/firefly-go/firefly"
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
 
 type Shape interface {
+	// Render the shape on the screen.
 	Draw()
+
+	// Render the shape moved by the given offset.
+	//
+	// Used by [Group] to draw children relative to the group position.
+	DrawAt(offset firefly.Point)
+
+	// Get the bounding box of the shape.
+	//
+	// The style of the returned [Rect] is always empty.
+	Bounds() Rect
+
+	// Check if the given point is inside of the shape.
+	Contains(p firefly.Point) bool
 }
 
+var (
+	_ Shape = Line{}
+	_ Shape = Rect{}
+	_ Shape = RoundedRect{}
+	_ Shape = Circle{}
+	_ Shape = Ellipse{}
+	_ Shape = Triangle{}
+	_ Shape = Arc{}
+	_ Shape = Sector{}
+)
+
 // A wrapper for [firefly.DrawLine].
 type Line struct {
 	A     firefly.Point
@@ -23,6 +63,53 @@ func (s Line) Draw() {
 	firefly.DrawLine(s.A, s.B, s.Style)
 }
 
+// DrawAt implements [Shape] interface.
+func (s Line) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s Line) Bounds() Rect {
+	return s.pad(boundsOf(s.A, s.B))
+}
+
+// Extend the bounding box to include the line stroke.
+func (s Line) pad(b Rect) Rect {
+	w := s.Style.Width / 2
+	b.Point = b.Point.Sub(firefly.P(w, w))
+	b.Size = b.Size.Add(firefly.S(w*2, w*2))
+	return b
+}
+
+// Contains implements [Shape] interface.
+//
+// The point is considered to be on the line if it's covered by the line stroke.
+func (s Line) Contains(p firefly.Point) bool {
+	r := float32(max(s.Style.Width, 1))/2 + .5
+	return segmentDistSquared(p, s.A, s.B) < r*r
+}
+
+// Move the shape by the given offset.
+func (s Line) Translate(d firefly.Point) Line {
+	s.A = s.A.Add(d)
+	s.B = s.B.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s Line) Scale(pivot firefly.Point, k float32) Line {
+	s.A = scalePoint(s.A, pivot, k)
+	s.B = scalePoint(s.B, pivot, k)
+	return s
+}
+
+// Rotate the shape clockwise around the given pivot point.
+func (s Line) Rotate(pivot firefly.Point, a firefly.Angle) Line {
+	s.A = rotatePoint(s.A, pivot, a)
+	s.B = rotatePoint(s.B, pivot, a)
+	return s
+}
+
 // A wrapper for [firefly.DrawRect].
 type Rect struct {
 	Point firefly.Point
@@ -35,6 +122,58 @@ func (s Rect) Draw() {
 	firefly.DrawRect(s.Point, s.Size, s.Style)
 }
 
+// DrawAt implements [Shape] interface.
+func (s Rect) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s Rect) Bounds() Rect {
+	return Rect{Point: s.Point, Size: s.Size}
+}
+
+// Contains implements [Shape] interface.
+func (s Rect) Contains(p firefly.Point) bool {
+	p = p.Sub(s.Point)
+	return p.X >= 0 && p.Y >= 0 && p.X < s.Size.W && p.Y < s.Size.H
+}
+
+// Move the shape by the given offset.
+func (s Rect) Translate(d firefly.Point) Rect {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s Rect) Scale(pivot firefly.Point, k float32) Rect {
+	s.Point = scalePoint(s.Point, pivot, k)
+	s.Size = firefly.S(scaleInt(s.Size.W, k), scaleInt(s.Size.H, k))
+	return s
+}
+
+// Check if the two rectangles overlap.
+func (s Rect) Intersects(r Rect) bool {
+	return s.Point.X < r.Point.X+r.Size.W &&
+		r.Point.X < s.Point.X+s.Size.W &&
+		s.Point.Y < r.Point.Y+r.Size.H &&
+		r.Point.Y < s.Point.Y+s.Size.H
+}
+
+// Get the smallest rectangle containing both rectangles.
+//
+// Empty rectangles are ignored.
+func (s Rect) Union(r Rect) Rect {
+	if s.Size.W <= 0 || s.Size.H <= 0 {
+		return r
+	}
+	if r.Size.W <= 0 || r.Size.H <= 0 {
+		return s
+	}
+	low := s.Point.ComponentMin(r.Point)
+	high := s.Point.Add(s.Size.Point()).ComponentMax(r.Point.Add(r.Size.Point()))
+	return Rect{Point: low, Size: high.Sub(low).Size(), Style: s.Style}
+}
+
 // A wrapper for [firefly.DrawRoundedRect].
 type RoundedRect struct {
 	Point  firefly.Point
@@ -48,6 +187,64 @@ func (s RoundedRect) Draw() {
 	firefly.DrawRoundedRect(s.Point, s.Size, s.Corner, s.Style)
 }
 
+// DrawAt implements [Shape] interface.
+func (s RoundedRect) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s RoundedRect) Bounds() Rect {
+	return Rect{Point: s.Point, Size: s.Size}
+}
+
+// Contains implements [Shape] interface.
+func (s RoundedRect) Contains(p firefly.Point) bool {
+	if !s.Bounds().Contains(p) {
+		return false
+	}
+	rx := s.Corner.W
+	ry := s.Corner.H
+	if rx <= 0 || ry <= 0 {
+		return true
+	}
+	// Find the center of the corner ellipse the point is next to.
+	var x, y int
+	switch {
+	case p.X < s.Point.X+rx:
+		x = s.Point.X + rx
+	case p.X >= s.Point.X+s.Size.W-rx:
+		x = s.Point.X + s.Size.W - rx
+	default:
+		return true
+	}
+	switch {
+	case p.Y < s.Point.Y+ry:
+		y = s.Point.Y + ry
+	case p.Y >= s.Point.Y+s.Size.H-ry:
+		y = s.Point.Y + s.Size.H - ry
+	default:
+		return true
+	}
+	dx, dy := offsetFrom(p, x*2, y*2)
+	nx := dx / float32(rx)
+	ny := dy / float32(ry)
+	return nx*nx+ny*ny <= 1
+}
+
+// Move the shape by the given offset.
+func (s RoundedRect) Translate(d firefly.Point) RoundedRect {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s RoundedRect) Scale(pivot firefly.Point, k float32) RoundedRect {
+	s.Point = scalePoint(s.Point, pivot, k)
+	s.Size = firefly.S(scaleInt(s.Size.W, k), scaleInt(s.Size.H, k))
+	s.Corner = firefly.S(scaleInt(s.Corner.W, k), scaleInt(s.Corner.H, k))
+	return s
+}
+
 // A wrapper for [firefly.DrawCircle].
 type Circle struct {
 	Point    firefly.Point
@@ -60,6 +257,51 @@ func (s Circle) Draw() {
 	firefly.DrawCircle(s.Point, s.Diameter, s.Style)
 }
 
+// DrawAt implements [Shape] interface.
+func (s Circle) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s Circle) Bounds() Rect {
+	return Rect{Point: s.Point, Size: firefly.S(s.Diameter, s.Diameter)}
+}
+
+// Contains implements [Shape] interface.
+func (s Circle) Contains(p firefly.Point) bool {
+	dx, dy := offsetFrom(p, s.Point.X*2+s.Diameter, s.Point.Y*2+s.Diameter)
+	r := float32(s.Diameter) / 2
+	return dx*dx+dy*dy <= r*r
+}
+
+// Move the shape by the given offset.
+func (s Circle) Translate(d firefly.Point) Circle {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s Circle) Scale(pivot firefly.Point, k float32) Circle {
+	d := scaleInt(s.Diameter, k)
+	center := scalePoint(s.center(), pivot, k)
+	s.Point = center.Sub(firefly.P(d/2, d/2))
+	s.Diameter = d
+	return s
+}
+
+// Rotate the shape clockwise around the given pivot point.
+//
+// Only the circle position changes.
+func (s Circle) Rotate(pivot firefly.Point, a firefly.Angle) Circle {
+	center := rotatePoint(s.center(), pivot, a)
+	s.Point = center.Sub(firefly.P(s.Diameter/2, s.Diameter/2))
+	return s
+}
+
+func (s Circle) center() firefly.Point {
+	return s.Point.Add(firefly.P(s.Diameter/2, s.Diameter/2))
+}
+
 // A wrapper for [firefly.DrawEllipse].
 type Ellipse struct {
 	Point firefly.Point
@@ -72,6 +314,40 @@ func (s Ellipse) Draw() {
 	firefly.DrawEllipse(s.Point, s.Size, s.Style)
 }
 
+// DrawAt implements [Shape] interface.
+func (s Ellipse) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s Ellipse) Bounds() Rect {
+	return Rect{Point: s.Point, Size: s.Size}
+}
+
+// Contains implements [Shape] interface.
+func (s Ellipse) Contains(p firefly.Point) bool {
+	if s.Size.W <= 0 || s.Size.H <= 0 {
+		return false
+	}
+	dx, dy := offsetFrom(p, s.Point.X*2+s.Size.W, s.Point.Y*2+s.Size.H)
+	nx := dx * 2 / float32(s.Size.W)
+	ny := dy * 2 / float32(s.Size.H)
+	return nx*nx+ny*ny <= 1
+}
+
+// Move the shape by the given offset.
+func (s Ellipse) Translate(d firefly.Point) Ellipse {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s Ellipse) Scale(pivot firefly.Point, k float32) Ellipse {
+	s.Point = scalePoint(s.Point, pivot, k)
+	s.Size = firefly.S(scaleInt(s.Size.W, k), scaleInt(s.Size.H, k))
+	return s
+}
+
 // A wrapper for [firefly.DrawTriangle].
 type Triangle struct {
 	A     firefly.Point
@@ -85,6 +361,50 @@ func (s Triangle) Draw() {
 	firefly.DrawTriangle(s.A, s.B, s.C, s.Style)
 }
 
+// DrawAt implements [Shape] interface.
+func (s Triangle) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s Triangle) Bounds() Rect {
+	return boundsOf(s.A, s.B, s.C)
+}
+
+// Contains implements [Shape] interface.
+func (s Triangle) Contains(p firefly.Point) bool {
+	d1 := cross(s.A, s.B, p)
+	d2 := cross(s.B, s.C, p)
+	d3 := cross(s.C, s.A, p)
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !hasNeg || !hasPos
+}
+
+// Move the shape by the given offset.
+func (s Triangle) Translate(d firefly.Point) Triangle {
+	s.A = s.A.Add(d)
+	s.B = s.B.Add(d)
+	s.C = s.C.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s Triangle) Scale(pivot firefly.Point, k float32) Triangle {
+	s.A = scalePoint(s.A, pivot, k)
+	s.B = scalePoint(s.B, pivot, k)
+	s.C = scalePoint(s.C, pivot, k)
+	return s
+}
+
+// Rotate the shape clockwise around the given pivot point.
+func (s Triangle) Rotate(pivot firefly.Point, a firefly.Angle) Triangle {
+	s.A = rotatePoint(s.A, pivot, a)
+	s.B = rotatePoint(s.B, pivot, a)
+	s.C = rotatePoint(s.C, pivot, a)
+	return s
+}
+
 // A wrapper for [firefly.DrawArc].
 type Arc struct {
 	Point    firefly.Point
@@ -99,6 +419,53 @@ func (s Arc) Draw() {
 	firefly.DrawArc(s.Point, s.Diameter, s.Start, s.Sweep, s.Style)
 }
 
+// DrawAt implements [Shape] interface.
+func (s Arc) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s Arc) Bounds() Rect {
+	center := s.Point.Add(firefly.P(s.Diameter/2, s.Diameter/2))
+	return boundsOf(arcPoints(center, s.Diameter, s.Start, s.Sweep)...)
+}
+
+// Contains implements [Shape] interface.
+//
+// The point is considered to be on the arc if it's covered by the arc stroke.
+func (s Arc) Contains(p firefly.Point) bool {
+	dx, dy := offsetFrom(p, s.Point.X*2+s.Diameter, s.Point.Y*2+s.Diameter)
+	r := float32(s.Diameter) / 2
+	w := float32(max(s.Style.StrokeWidth, 1))
+	dist := tinymath.Sqrt(dx*dx + dy*dy)
+	if dist > r+.5 || dist < r-w-.5 {
+		return false
+	}
+	return inSweep(dx, dy, s.Start, s.Sweep)
+}
+
+// Move the shape by the given offset.
+func (s Arc) Translate(d firefly.Point) Arc {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s Arc) Scale(pivot firefly.Point, k float32) Arc {
+	c := Circle{Point: s.Point, Diameter: s.Diameter}.Scale(pivot, k)
+	s.Point = c.Point
+	s.Diameter = c.Diameter
+	return s
+}
+
+// Rotate the shape clockwise around the given pivot point.
+func (s Arc) Rotate(pivot firefly.Point, a firefly.Angle) Arc {
+	c := Circle{Point: s.Point, Diameter: s.Diameter}.Rotate(pivot, a)
+	s.Point = c.Point
+	s.Start = s.Start.Add(a).Normalize()
+	return s
+}
+
 // A wrapper for [firefly.DrawSector].
 type Sector struct {
 	Point    firefly.Point
@@ -112,3 +479,46 @@ type Sector struct {
 func (s Sector) Draw() {
 	firefly.DrawSector(s.Point, s.Diameter, s.Start, s.Sweep, s.Style)
 }
+
+// DrawAt implements [Shape] interface.
+func (s Sector) DrawAt(offset firefly.Point) {
+	s.Translate(offset).Draw()
+}
+
+// Bounds implements [Shape] interface.
+func (s Sector) Bounds() Rect {
+	center := s.Point.Add(firefly.P(s.Diameter/2, s.Diameter/2))
+	points := arcPoints(center, s.Diameter, s.Start, s.Sweep)
+	return boundsOf(append(points, center)...)
+}
+
+// Contains implements [Shape] interface.
+func (s Sector) Contains(p firefly.Point) bool {
+	if !(Circle{Point: s.Point, Diameter: s.Diameter}).Contains(p) {
+		return false
+	}
+	dx, dy := offsetFrom(p, s.Point.X*2+s.Diameter, s.Point.Y*2+s.Diameter)
+	return inSweep(dx, dy, s.Start, s.Sweep)
+}
+
+// Move the shape by the given offset.
+func (s Sector) Translate(d firefly.Point) Sector {
+	s.Point = s.Point.Add(d)
+	return s
+}
+
+// Scale the shape relative to the given pivot point.
+func (s Sector) Scale(pivot firefly.Point, k float32) Sector {
+	c := Circle{Point: s.Point, Diameter: s.Diameter}.Scale(pivot, k)
+	s.Point = c.Point
+	s.Diameter = c.Diameter
+	return s
+}
+
+// Rotate the shape clockwise around the given pivot point.
+func (s Sector) Rotate(pivot firefly.Point, a firefly.Angle) Sector {
+	c := Circle{Point: s.Point, Diameter: s.Diameter}.Rotate(pivot, a)
+	s.Point = c.Point
+	s.Start = s.Start.Add(a).Normalize()
+	return s
+}
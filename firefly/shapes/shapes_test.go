@@ -0,0 +1,137 @@
+package shapes_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+)
+
+func TestShape_Contains(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	S := firefly.S
+	rounded := shapes.RoundedRect{Point: P(0, 0), Size: S(20, 20), Corner: S(5, 5)}
+	polygon := shapes.Polygon{Points: []firefly.Point{P(0, 0), P(10, 0), P(10, 10), P(5, 2), P(0, 10)}}
+	polyline := shapes.Polyline{Points: []firefly.Point{P(0, 0), P(10, 0), P(10, 10)}}
+	tests := []struct {
+		name  string
+		shape shapes.Shape
+		point firefly.Point
+		want  bool
+	}{
+		{name: "rect inside", shape: shapes.Rect{Point: P(10, 10), Size: S(5, 5)}, point: P(12, 14), want: true},
+		{name: "rect corner", shape: shapes.Rect{Point: P(10, 10), Size: S(5, 5)}, point: P(10, 10), want: true},
+		{name: "rect outside", shape: shapes.Rect{Point: P(10, 10), Size: S(5, 5)}, point: P(15, 12), want: false},
+		{name: "rounded rect middle", shape: rounded, point: P(10, 0), want: true},
+		{name: "rounded rect cut corner", shape: rounded, point: P(0, 0), want: false},
+		{name: "circle center", shape: shapes.Circle{Point: P(0, 0), Diameter: 10}, point: P(5, 5), want: true},
+		{name: "circle edge", shape: shapes.Circle{Point: P(0, 0), Diameter: 10}, point: P(0, 5), want: true},
+		{name: "circle bbox corner", shape: shapes.Circle{Point: P(0, 0), Diameter: 10}, point: P(0, 0), want: false},
+		{name: "ellipse inside", shape: shapes.Ellipse{Point: P(0, 0), Size: S(20, 10)}, point: P(18, 5), want: true},
+		{name: "ellipse outside", shape: shapes.Ellipse{Point: P(0, 0), Size: S(20, 10)}, point: P(18, 1), want: false},
+		{name: "triangle inside", shape: shapes.Triangle{A: P(0, 0), B: P(10, 0), C: P(0, 10)}, point: P(2, 2), want: true},
+		{name: "triangle vertex", shape: shapes.Triangle{A: P(0, 0), B: P(10, 0), C: P(0, 10)}, point: P(10, 0), want: true},
+		{name: "triangle outside", shape: shapes.Triangle{A: P(0, 0), B: P(10, 0), C: P(0, 10)}, point: P(8, 8), want: false},
+		{name: "line on", shape: shapes.Line{A: P(0, 0), B: P(10, 10)}, point: P(5, 5), want: true},
+		{name: "line off", shape: shapes.Line{A: P(0, 0), B: P(10, 10)}, point: P(5, 8), want: false},
+		{name: "thick line", shape: shapes.Line{A: P(0, 0), B: P(10, 0), Style: firefly.L(1, 6)}, point: P(5, 3), want: true},
+		{name: "polygon inside", shape: polygon, point: P(8, 5), want: true},
+		{name: "polygon notch", shape: polygon, point: P(5, 6), want: false},
+		{name: "polyline on", shape: polyline, point: P(10, 5), want: true},
+		{
+			name:  "sector inside",
+			shape: shapes.Sector{Point: P(0, 0), Diameter: 20, Start: firefly.Degrees(0), Sweep: firefly.Degrees(90)},
+			point: P(15, 15), want: true,
+		},
+		{
+			name:  "sector outside sweep",
+			shape: shapes.Sector{Point: P(0, 0), Diameter: 20, Start: firefly.Degrees(0), Sweep: firefly.Degrees(90)},
+			point: P(5, 5), want: false,
+		},
+		{
+			name:  "arc on stroke",
+			shape: shapes.Arc{Point: P(0, 0), Diameter: 20, Start: firefly.Degrees(180), Sweep: firefly.Degrees(90)},
+			point: P(0, 9), want: true,
+		},
+		{
+			name:  "arc inside circle",
+			shape: shapes.Arc{Point: P(0, 0), Diameter: 20, Start: firefly.Degrees(180), Sweep: firefly.Degrees(90)},
+			point: P(6, 6), want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := test.shape.Contains(test.point)
+			if got != test.want {
+				t.Errorf("point: {%d, %d}, want %t, but got %t", test.point.X, test.point.Y, test.want, got)
+			}
+		})
+	}
+}
+
+func TestShape_Bounds(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	S := firefly.S
+	tests := []struct {
+		name  string
+		shape shapes.Shape
+		want  shapes.Rect
+	}{
+		{name: "rect", shape: shapes.Rect{Point: P(1, 2), Size: S(3, 4)}, want: shapes.Rect{Point: P(1, 2), Size: S(3, 4)}},
+		{name: "circle", shape: shapes.Circle{Point: P(1, 2), Diameter: 5}, want: shapes.Rect{Point: P(1, 2), Size: S(5, 5)}},
+		{name: "line", shape: shapes.Line{A: P(5, 1), B: P(1, 3)}, want: shapes.Rect{Point: P(1, 1), Size: S(5, 3)}},
+		{
+			name:  "triangle",
+			shape: shapes.Triangle{A: P(5, 1), B: P(1, 3), C: P(2, 7)},
+			want:  shapes.Rect{Point: P(1, 1), Size: S(5, 7)},
+		},
+		{
+			name:  "sector quarter",
+			shape: shapes.Sector{Point: P(0, 0), Diameter: 20, Start: firefly.Degrees(0), Sweep: firefly.Degrees(90)},
+			want:  shapes.Rect{Point: P(10, 10), Size: S(11, 11)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := test.shape.Bounds()
+			if got != test.want {
+				t.Errorf("want %v, but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	line := shapes.Line{A: P(10, 10), B: P(20, 10)}
+
+	got := line.Translate(P(5, -5))
+	want := shapes.Line{A: P(15, 5), B: P(25, 5)}
+	if got != want {
+		t.Errorf("translate: want %v, but got %v", want, got)
+	}
+
+	got = line.Scale(P(10, 10), 2)
+	want = shapes.Line{A: P(10, 10), B: P(30, 10)}
+	if got != want {
+		t.Errorf("scale: want %v, but got %v", want, got)
+	}
+
+	got = line.Rotate(P(10, 10), firefly.Degrees(90))
+	want = shapes.Line{A: P(10, 10), B: P(10, 20)}
+	if got != want {
+		t.Errorf("rotate: want %v, but got %v", want, got)
+	}
+
+	circle := shapes.Circle{Point: P(0, 0), Diameter: 10}.Scale(P(5, 5), 2)
+	if circle.Point != P(0-5, 0-5) || circle.Diameter != 20 {
+		t.Errorf("scale circle: got %v", circle)
+	}
+}
@@ -0,0 +1,71 @@
+package svg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// A few of the most common CSS color names.
+var namedColors = map[string]firefly.RGB{
+	"black":   {},
+	"white":   {R: 0xff, G: 0xff, B: 0xff},
+	"red":     {R: 0xff},
+	"lime":    {G: 0xff},
+	"green":   {G: 0x80},
+	"blue":    {B: 0xff},
+	"yellow":  {R: 0xff, G: 0xff},
+	"cyan":    {G: 0xff, B: 0xff},
+	"aqua":    {G: 0xff, B: 0xff},
+	"magenta": {R: 0xff, B: 0xff},
+	"fuchsia": {R: 0xff, B: 0xff},
+	"orange":  {R: 0xff, G: 0xa5},
+	"purple":  {R: 0x80, B: 0x80},
+	"navy":    {B: 0x80},
+	"teal":    {G: 0x80, B: 0x80},
+	"maroon":  {R: 0x80},
+	"olive":   {R: 0x80, G: 0x80},
+	"gray":    {R: 0x80, G: 0x80, B: 0x80},
+	"grey":    {R: 0x80, G: 0x80, B: 0x80},
+	"silver":  {R: 0xc0, G: 0xc0, B: 0xc0},
+}
+
+// Parse the SVG color and find the nearest color in the palette.
+func (p *parser) color(s string) (firefly.Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "none" || s == "transparent" {
+		return firefly.ColorNone, nil
+	}
+	rgb, err := parseRGB(s)
+	if err != nil {
+		return firefly.ColorNone, err
+	}
+	return p.opts.Palette.Nearest(rgb), nil
+}
+
+func parseRGB(s string) (firefly.RGB, error) {
+	if rgb, ok := namedColors[s]; ok {
+		return rgb, nil
+	}
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return firefly.RGB{}, fmt.Errorf("%w: bad color %q", ErrInvalid, s)
+		}
+		return firefly.NewRGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+	}
+	if args, ok := strings.CutPrefix(s, "rgb("); ok {
+		args = strings.TrimSuffix(args, ")")
+		nums, err := parseNumbers(args)
+		if err != nil || len(nums) != 3 {
+			return firefly.RGB{}, fmt.Errorf("%w: bad color %q", ErrInvalid, s)
+		}
+		return firefly.NewRGB(uint8(nums[0]), uint8(nums[1]), uint8(nums[2])), nil
+	}
+	return firefly.RGB{}, fmt.Errorf("%w: color %q", ErrUnsupported, s)
+}
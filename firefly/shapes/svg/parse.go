@@ -0,0 +1,329 @@
+package svg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse a single number, ignoring the "px" unit suffix.
+func parseNumber(s string) (float32, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "px")
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad number %q", ErrInvalid, s)
+	}
+	return float32(v), nil
+}
+
+// Parse numbers separated by spaces and/or commas.
+func parseNumbers(s string) ([]float32, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	nums := make([]float32, len(fields))
+	for i, field := range fields {
+		v, err := parseNumber(field)
+		if err != nil {
+			return nil, err
+		}
+		nums[i] = v
+	}
+	return nums, nil
+}
+
+// Parse the given numeric attributes. Missing attributes are zero.
+func numAttrs(attrs map[string]string, names ...string) ([]float32, error) {
+	nums := make([]float32, len(names))
+	for i, name := range names {
+		val, ok := attrs[name]
+		if !ok {
+			continue
+		}
+		v, err := parseNumber(val)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		nums[i] = v
+	}
+	return nums, nil
+}
+
+// Parse the transform attribute. Only translate is supported.
+func parseTranslate(s string) (float32, float32, error) {
+	var dx, dy float32
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		name, rest, ok := strings.Cut(s, "(")
+		if !ok {
+			return 0, 0, fmt.Errorf("%w: bad transform %q", ErrInvalid, s)
+		}
+		if strings.TrimSpace(name) != "translate" {
+			return 0, 0, fmt.Errorf("%w: transform %s", ErrUnsupported, name)
+		}
+		args, rest, ok := strings.Cut(rest, ")")
+		if !ok {
+			return 0, 0, fmt.Errorf("%w: bad transform %q", ErrInvalid, s)
+		}
+		nums, err := parseNumbers(args)
+		if err != nil {
+			return 0, 0, err
+		}
+		switch len(nums) {
+		case 1:
+			dx += nums[0]
+		case 2:
+			dx += nums[0]
+			dy += nums[1]
+		default:
+			return 0, 0, fmt.Errorf("%w: bad translate %q", ErrInvalid, args)
+		}
+		s = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+	}
+	return dx, dy, nil
+}
+
+type vec struct {
+	x float32
+	y float32
+}
+
+type subpath struct {
+	points []vec
+	closed bool
+}
+
+// The number of line segments used to approximate a bézier curve.
+const curveSegments = 8
+
+// Parse the path data (the d attribute of the path element).
+func parsePath(d string) ([]subpath, error) {
+	lex := pathLexer{s: d}
+	var paths []subpath
+	var cur subpath
+	var pos, start, ctrl vec
+	var hasCtrl bool
+	var cmd byte
+	finish := func() {
+		if len(cur.points) > 1 {
+			paths = append(paths, cur)
+		}
+		cur = subpath{}
+	}
+	for {
+		next, ok := lex.command()
+		if ok {
+			cmd = next
+		} else if lex.done() {
+			break
+		} else if cmd == 0 {
+			return nil, fmt.Errorf("%w: path must start with a command", ErrInvalid)
+		}
+		rel := cmd >= 'a'
+		origin := vec{}
+		if rel {
+			origin = pos
+		}
+		prevCtrl, hadCtrl := ctrl, hasCtrl
+		hasCtrl = false
+		switch cmd | 0x20 {
+		case 'm':
+			p, err := lex.point(origin)
+			if err != nil {
+				return nil, err
+			}
+			finish()
+			pos, start = p, p
+			cur.points = append(cur.points, p)
+			// Subsequent coordinate pairs are implicit lineto commands.
+			cmd = 'L' | (cmd & 0x20)
+		case 'l':
+			p, err := lex.point(origin)
+			if err != nil {
+				return nil, err
+			}
+			pos = p
+			cur.points = append(cur.points, p)
+		case 'h':
+			x, err := lex.number()
+			if err != nil {
+				return nil, err
+			}
+			pos.x = x + origin.x
+			cur.points = append(cur.points, pos)
+		case 'v':
+			y, err := lex.number()
+			if err != nil {
+				return nil, err
+			}
+			pos.y = y + origin.y
+			cur.points = append(cur.points, pos)
+		case 'z':
+			cur.closed = true
+			finish()
+			pos = start
+			cur.points = append(cur.points, pos)
+			// Coordinates can't follow closepath without a new command.
+			cmd = 0
+		case 'c', 's':
+			var c1 vec
+			if cmd|0x20 == 'c' {
+				p, err := lex.point(origin)
+				if err != nil {
+					return nil, err
+				}
+				c1 = p
+			} else {
+				c1 = reflect(prevCtrl, pos, hadCtrl)
+			}
+			c2, err := lex.point(origin)
+			if err != nil {
+				return nil, err
+			}
+			end, err := lex.point(origin)
+			if err != nil {
+				return nil, err
+			}
+			cur.points = appendCubic(cur.points, pos, c1, c2, end)
+			pos, ctrl, hasCtrl = end, c2, true
+		case 'q', 't':
+			var c vec
+			if cmd|0x20 == 'q' {
+				p, err := lex.point(origin)
+				if err != nil {
+					return nil, err
+				}
+				c = p
+			} else {
+				c = reflect(prevCtrl, pos, hadCtrl)
+			}
+			end, err := lex.point(origin)
+			if err != nil {
+				return nil, err
+			}
+			cur.points = appendQuad(cur.points, pos, c, end)
+			pos, ctrl, hasCtrl = end, c, true
+		case 'a':
+			return nil, fmt.Errorf("%w: path arcs", ErrUnsupported)
+		default:
+			return nil, fmt.Errorf("%w: unknown path command %c", ErrInvalid, cmd)
+		}
+	}
+	finish()
+	return paths, nil
+}
+
+// Reflect the control point relative to the current point.
+//
+// If there is no previous control point, the current point is used.
+func reflect(ctrl, pos vec, ok bool) vec {
+	if !ok {
+		return pos
+	}
+	return vec{x: 2*pos.x - ctrl.x, y: 2*pos.y - ctrl.y}
+}
+
+func appendCubic(points []vec, p0, p1, p2, p3 vec) []vec {
+	for i := 1; i <= curveSegments; i++ {
+		t := float32(i) / curveSegments
+		u := 1 - t
+		a := u * u * u
+		b := 3 * u * u * t
+		c := 3 * u * t * t
+		d := t * t * t
+		points = append(points, vec{
+			x: a*p0.x + b*p1.x + c*p2.x + d*p3.x,
+			y: a*p0.y + b*p1.y + c*p2.y + d*p3.y,
+		})
+	}
+	return points
+}
+
+func appendQuad(points []vec, p0, p1, p2 vec) []vec {
+	for i := 1; i <= curveSegments; i++ {
+		t := float32(i) / curveSegments
+		u := 1 - t
+		a := u * u
+		b := 2 * u * t
+		c := t * t
+		points = append(points, vec{
+			x: a*p0.x + b*p1.x + c*p2.x,
+			y: a*p0.y + b*p1.y + c*p2.y,
+		})
+	}
+	return points
+}
+
+// Tokenizer for the path data.
+type pathLexer struct {
+	s string
+}
+
+func (l *pathLexer) skipSeparators() {
+	l.s = strings.TrimLeft(l.s, " \t\r\n,")
+}
+
+func (l *pathLexer) done() bool {
+	l.skipSeparators()
+	return l.s == ""
+}
+
+// Read a command letter if the next token is a command.
+func (l *pathLexer) command() (byte, bool) {
+	l.skipSeparators()
+	if l.s == "" {
+		return 0, false
+	}
+	c := l.s[0]
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		if c == 'e' || c == 'E' {
+			return 0, false
+		}
+		l.s = l.s[1:]
+		return c, true
+	}
+	return 0, false
+}
+
+func (l *pathLexer) number() (float32, error) {
+	l.skipSeparators()
+	end := 0
+	seenDot := false
+	seenExp := false
+	for end < len(l.s) {
+		c := l.s[end]
+		switch {
+		case c >= '0' && c <= '9':
+		case (c == '-' || c == '+') && (end == 0 || l.s[end-1] == 'e' || l.s[end-1] == 'E'):
+		case c == '.' && !seenDot && !seenExp:
+			seenDot = true
+		case (c == 'e' || c == 'E') && !seenExp && end > 0:
+			seenExp = true
+		default:
+			return l.take(end)
+		}
+		end++
+	}
+	return l.take(end)
+}
+
+func (l *pathLexer) take(end int) (float32, error) {
+	if end == 0 {
+		return 0, fmt.Errorf("%w: expected number in path at %q", ErrInvalid, l.s)
+	}
+	v, err := parseNumber(l.s[:end])
+	l.s = l.s[end:]
+	return v, err
+}
+
+func (l *pathLexer) point(origin vec) (vec, error) {
+	x, err := l.number()
+	if err != nil {
+		return vec{}, err
+	}
+	y, err := l.number()
+	if err != nil {
+		return vec{}, err
+	}
+	return vec{x: x + origin.x, y: y + origin.y}, nil
+}
@@ -0,0 +1,339 @@
+// Import vector images from a subset of SVG as shapes.
+//
+// Supported elements are rect, circle, ellipse, line, polyline, polygon,
+// and path (without arcs; curves are approximated with straight lines).
+// Groups (g) are supported, including inherited styles and translate transforms.
+// Fill and stroke colors are mapped to the nearest color in the palette.
+//
+// The parser can be used at runtime but it's quite heavy.
+// Prefer converting SVG files on the host at build time using svg2shapes:
+//
+//	go run github.com/firefly-zero/firefly-go/cmd/svg2shapes -o icon.go icon.svg
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/palette"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+	"github.com/orsinium-labs/tinymath"
+)
+
+var (
+	// Returned if the SVG uses a feature that cannot be converted into shapes.
+	ErrUnsupported = errors.New("unsupported SVG feature")
+
+	// Returned if the SVG is malformed.
+	ErrInvalid = errors.New("invalid SVG")
+)
+
+// Options for [Parse].
+type Options struct {
+	// The palette in which the nearest color is searched for fill and stroke.
+	//
+	// If nil, the default palette (SWEETIE-16) is used.
+	Palette *palette.Palette
+
+	// All coordinates and sizes are multiplied by this value.
+	//
+	// If zero, the image is not scaled.
+	Scale float32
+}
+
+// Parse the given SVG image into a list of shapes.
+func Parse(data []byte, opts Options) ([]shapes.Shape, error) {
+	if opts.Scale == 0 {
+		opts.Scale = 1
+	}
+	if opts.Palette == nil {
+		opts.Palette = &palette.Sweetie16
+	}
+	p := parser{
+		dec:    xml.NewDecoder(bytes.NewReader(data)),
+		opts:   opts,
+		shapes: make([]shapes.Shape, 0),
+	}
+	err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return p.shapes, nil
+}
+
+// Inheritable properties of an element.
+type state struct {
+	fill        firefly.Color
+	stroke      firefly.Color
+	strokeWidth float32
+	dx          float32
+	dy          float32
+}
+
+type parser struct {
+	dec    *xml.Decoder
+	opts   Options
+	shapes []shapes.Shape
+	stack  []state
+}
+
+func (p *parser) parse() error {
+	p.stack = []state{{fill: p.opts.Palette.Nearest(firefly.RGB{}), strokeWidth: 1}}
+	for {
+		token, err := p.dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			err = p.start(token)
+			if err != nil {
+				return fmt.Errorf("<%s>: %w", token.Name.Local, err)
+			}
+		case xml.EndElement:
+			p.stack = p.stack[:len(p.stack)-1]
+		}
+	}
+}
+
+func (p *parser) start(el xml.StartElement) error {
+	st := p.stack[len(p.stack)-1]
+	attrs := make(map[string]string, len(el.Attr))
+	for _, attr := range el.Attr {
+		attrs[attr.Name.Local] = attr.Value
+	}
+	err := p.applyStyle(&st, attrs)
+	if err != nil {
+		return err
+	}
+	p.stack = append(p.stack, st)
+	switch el.Name.Local {
+	case "svg":
+		return p.viewBox(attrs)
+	case "g":
+		return nil
+	case "rect":
+		return p.rect(st, attrs)
+	case "circle":
+		return p.circle(st, attrs)
+	case "ellipse":
+		return p.ellipse(st, attrs)
+	case "line":
+		return p.line(st, attrs)
+	case "polyline":
+		return p.poly(st, attrs, false)
+	case "polygon":
+		return p.poly(st, attrs, true)
+	case "path":
+		return p.path(st, attrs)
+	case "text", "image", "use", "foreignObject", "switch":
+		return ErrUnsupported
+	default:
+		// Non-graphical elements, like title, desc, metadata, or defs.
+		p.stack = p.stack[:len(p.stack)-1]
+		return p.dec.Skip()
+	}
+}
+
+// Apply the style attributes of the element to the inherited state.
+func (p *parser) applyStyle(st *state, attrs map[string]string) error {
+	props := make(map[string]string)
+	for _, name := range []string{"fill", "stroke", "stroke-width", "transform"} {
+		if val, ok := attrs[name]; ok {
+			props[name] = val
+		}
+	}
+	// The style attribute takes precedence over presentation attributes.
+	for decl := range strings.SplitSeq(attrs["style"], ";") {
+		name, val, ok := strings.Cut(decl, ":")
+		if ok {
+			props[strings.TrimSpace(name)] = strings.TrimSpace(val)
+		}
+	}
+	var err error
+	if val, ok := props["fill"]; ok {
+		st.fill, err = p.color(val)
+		if err != nil {
+			return err
+		}
+	}
+	if val, ok := props["stroke"]; ok {
+		st.stroke, err = p.color(val)
+		if err != nil {
+			return err
+		}
+	}
+	if val, ok := props["stroke-width"]; ok {
+		st.strokeWidth, err = parseNumber(val)
+		if err != nil {
+			return err
+		}
+	}
+	if val, ok := props["transform"]; ok {
+		dx, dy, err := parseTranslate(val)
+		if err != nil {
+			return err
+		}
+		st.dx += dx
+		st.dy += dy
+	}
+	return nil
+}
+
+func (p *parser) viewBox(attrs map[string]string) error {
+	val, ok := attrs["viewBox"]
+	if !ok {
+		return nil
+	}
+	nums, err := parseNumbers(val)
+	if err != nil {
+		return err
+	}
+	if len(nums) != 4 {
+		return fmt.Errorf("%w: viewBox must have 4 numbers", ErrInvalid)
+	}
+	st := &p.stack[len(p.stack)-1]
+	st.dx -= nums[0]
+	st.dy -= nums[1]
+	return nil
+}
+
+func (p *parser) rect(st state, attrs map[string]string) error {
+	nums, err := numAttrs(attrs, "x", "y", "width", "height", "rx", "ry")
+	if err != nil {
+		return err
+	}
+	x, y, w, h, rx, ry := nums[0], nums[1], nums[2], nums[3], nums[4], nums[5]
+	if rx == 0 {
+		rx = ry
+	}
+	if ry == 0 {
+		ry = rx
+	}
+	point := p.point(st, x, y)
+	size := firefly.S(p.length(w), p.length(h))
+	style := p.style(st)
+	if rx == 0 {
+		p.add(shapes.Rect{Point: point, Size: size, Style: style}, st)
+		return nil
+	}
+	corner := firefly.S(p.length(rx), p.length(ry))
+	p.add(shapes.RoundedRect{Point: point, Size: size, Corner: corner, Style: style}, st)
+	return nil
+}
+
+func (p *parser) circle(st state, attrs map[string]string) error {
+	nums, err := numAttrs(attrs, "cx", "cy", "r")
+	if err != nil {
+		return err
+	}
+	cx, cy, r := nums[0], nums[1], nums[2]
+	point := p.point(st, cx-r, cy-r)
+	p.add(shapes.Circle{Point: point, Diameter: p.length(r * 2), Style: p.style(st)}, st)
+	return nil
+}
+
+func (p *parser) ellipse(st state, attrs map[string]string) error {
+	nums, err := numAttrs(attrs, "cx", "cy", "rx", "ry")
+	if err != nil {
+		return err
+	}
+	cx, cy, rx, ry := nums[0], nums[1], nums[2], nums[3]
+	point := p.point(st, cx-rx, cy-ry)
+	size := firefly.S(p.length(rx*2), p.length(ry*2))
+	p.add(shapes.Ellipse{Point: point, Size: size, Style: p.style(st)}, st)
+	return nil
+}
+
+func (p *parser) line(st state, attrs map[string]string) error {
+	nums, err := numAttrs(attrs, "x1", "y1", "x2", "y2")
+	if err != nil {
+		return err
+	}
+	if st.stroke == firefly.ColorNone {
+		return nil
+	}
+	a := p.point(st, nums[0], nums[1])
+	b := p.point(st, nums[2], nums[3])
+	p.add(shapes.Line{A: a, B: b, Style: p.style(st).LineStyle()}, st)
+	return nil
+}
+
+func (p *parser) poly(st state, attrs map[string]string, closed bool) error {
+	nums, err := parseNumbers(attrs["points"])
+	if err != nil {
+		return err
+	}
+	if len(nums)%2 != 0 {
+		return fmt.Errorf("%w: odd number of coordinates", ErrInvalid)
+	}
+	points := make([]firefly.Point, 0, len(nums)/2)
+	for i := 0; i < len(nums); i += 2 {
+		points = append(points, p.point(st, nums[i], nums[i+1]))
+	}
+	p.addPath(st, points, closed)
+	return nil
+}
+
+func (p *parser) path(st state, attrs map[string]string) error {
+	subpaths, err := parsePath(attrs["d"])
+	if err != nil {
+		return err
+	}
+	for _, sub := range subpaths {
+		points := make([]firefly.Point, len(sub.points))
+		for i, v := range sub.points {
+			points[i] = p.point(st, v.x, v.y)
+		}
+		p.addPath(st, points, sub.closed)
+	}
+	return nil
+}
+
+// Add a polygon or polyline.
+//
+// Like in SVG, open paths with a fill are filled as if they were closed.
+func (p *parser) addPath(st state, points []firefly.Point, closed bool) {
+	if len(points) < 2 {
+		return
+	}
+	style := p.style(st)
+	if closed || style.FillColor != firefly.ColorNone {
+		p.add(shapes.Polygon{Points: points, Style: style}, st)
+		return
+	}
+	p.add(shapes.Polyline{Points: points, Style: style.LineStyle()}, st)
+}
+
+// Add the shape unless it is invisible.
+func (p *parser) add(s shapes.Shape, st state) {
+	if st.fill == firefly.ColorNone && st.stroke == firefly.ColorNone {
+		return
+	}
+	p.shapes = append(p.shapes, s)
+}
+
+func (p *parser) style(st state) firefly.Style {
+	style := firefly.Style{FillColor: st.fill}
+	if st.stroke != firefly.ColorNone {
+		style.StrokeColor = st.stroke
+		style.StrokeWidth = max(p.length(st.strokeWidth), 1)
+	}
+	return style
+}
+
+func (p *parser) point(st state, x, y float32) firefly.Point {
+	return firefly.P(p.length(x+st.dx), p.length(y+st.dy))
+}
+
+func (p *parser) length(v float32) int {
+	return int(tinymath.Round(v * p.opts.Scale))
+}
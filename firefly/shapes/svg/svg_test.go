@@ -0,0 +1,80 @@
+package svg_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+	"github.com/firefly-zero/firefly-go/firefly/shapes/svg"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	P := firefly.P
+	S := firefly.S
+	data := []byte(`
+		<svg xmlns="http://www.w3.org/2000/svg" viewBox="10 10 100 100">
+			<title>icon</title>
+			<g fill="#B13E53" transform="translate(5, 5)">
+				<rect x="10" y="10" width="20" height="10" stroke="white" stroke-width="2"/>
+				<circle cx="50" cy="50" r="10" style="fill: none; stroke: #41a6f6"/>
+			</g>
+			<path d="M10 10 L30 10 l0 20 Z M 50 50 h 10 v 10" fill="none" stroke="black"/>
+			<line x1="10" y1="10" x2="20" y2="20"/>
+		</svg>
+	`)
+	got, err := svg.Parse(data, svg.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []shapes.Shape{
+		shapes.Rect{
+			Point: P(5, 5), Size: S(20, 10),
+			Style: firefly.Style{FillColor: firefly.ColorRed, StrokeColor: firefly.ColorWhite, StrokeWidth: 2},
+		},
+		shapes.Circle{
+			Point: P(35, 35), Diameter: 20,
+			Style: firefly.Outlined(firefly.ColorLightBlue, 1),
+		},
+		shapes.Polygon{
+			Points: []firefly.Point{P(0, 0), P(20, 0), P(20, 20)},
+			Style:  firefly.Outlined(firefly.ColorBlack, 1),
+		},
+		shapes.Polyline{
+			Points: []firefly.Point{P(40, 40), P(50, 40), P(50, 50)},
+			Style:  firefly.L(firefly.ColorBlack, 1),
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d shapes, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if !shapesEqual(got[i], want[i]) {
+			t.Errorf("shape #%d: want %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	t.Parallel()
+	tests := []string{
+		`<svg><text>hi</text></svg>`,
+		`<svg><rect transform="rotate(45)" width="1" height="1"/></svg>`,
+		`<svg><path d="M0 0 A 5 5 0 0 1 10 10"/></svg>`,
+		`<svg><rect fill="url(#grad)" width="1" height="1"/></svg>`,
+	}
+	for _, data := range tests {
+		_, err := svg.Parse([]byte(data), svg.Options{})
+		if !errors.Is(err, svg.ErrUnsupported) {
+			t.Errorf("%s: want ErrUnsupported, got %v", data, err)
+		}
+	}
+}
+
+func shapesEqual(a, b shapes.Shape) bool {
+	if e, ok := a.(shapes.Equaler); ok {
+		return e.Eq(b)
+	}
+	return a == b
+}
@@ -0,0 +1,200 @@
+// Outlines, shadows, and silhouettes for sprites.
+//
+// All effects produce a new [firefly.Canvas] and are cached,
+// so it's fine to call them every frame and draw the result with [firefly.DrawImage].
+// The effects take a [firefly.SubImage]. To apply an effect to a whole [firefly.Image],
+// use [firefly.Image.Sub] with [firefly.Image.Size].
+//
+// The cache is keyed by the image memory, so if you apply an effect to a canvas
+// and then draw something else on that canvas, call [ClearCache].
+package spritefx
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+type kind uint8
+
+const (
+	outline kind = iota
+	shadow
+	silhouette
+)
+
+// Everything that defines the result of an effect.
+type key struct {
+	// The start of the image memory, identifies the image.
+	raw *byte
+	// The transparency color of the source image, which can be changed at any time.
+	transp firefly.Color
+	point  firefly.Point
+	size   firefly.Size
+	kind   kind
+	color  firefly.Color
+	offset firefly.Point
+}
+
+// Generated canvases for each sprite and effect.
+var cache = make(map[key]firefly.Canvas)
+
+// Remove all generated canvases from the cache.
+//
+// Call it when sprites with effects are not needed anymore to free the memory.
+func ClearCache() {
+	clear(cache)
+}
+
+// The sprite with a 1px outline around all its non-transparent pixels.
+//
+// The result is 2 pixels wider and 2 pixels higher than the sprite,
+// so draw it 1 pixel up and to the left from where the sprite would be drawn.
+// Don't modify the returned canvas, it's shared by all calls with the same arguments.
+func Outline(img firefly.SubImage, c firefly.Color) firefly.Canvas {
+	return cached(key{kind: outline, color: c}, img, func() firefly.Canvas {
+		size := img.Size()
+		canvas, transp := newCanvas(img, firefly.S(size.W+2, size.H+2), c)
+		neighbors := [4]firefly.Point{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}}
+		for y := -1; y <= size.H; y++ {
+			for x := -1; x <= size.W; x++ {
+				p := firefly.P(x, y)
+				if opaque(img, transp, p) {
+					continue
+				}
+				for _, n := range neighbors {
+					if opaque(img, transp, p.Add(n)) {
+						canvas.SetPixel(p.Add(firefly.P(1, 1)), c)
+						break
+					}
+				}
+			}
+		}
+		copyOpaque(canvas, img, transp, firefly.P(1, 1))
+		return canvas
+	})
+}
+
+// The sprite with a hard drop shadow of a single color at the given offset.
+//
+// The result is bigger than the sprite by the offset. If the offset is negative,
+// draw the result offset up or to the left from where the sprite would be drawn.
+// Don't modify the returned canvas, it's shared by all calls with the same arguments.
+func Shadow(img firefly.SubImage, offset firefly.Point, c firefly.Color) firefly.Canvas {
+	return cached(key{kind: shadow, color: c, offset: offset}, img, func() firefly.Canvas {
+		size := img.Size()
+		full := firefly.S(size.W+abs(offset.X), size.H+abs(offset.Y))
+		canvas, transp := newCanvas(img, full, c)
+		origin := firefly.P(max(-offset.X, 0), max(-offset.Y, 0))
+		for y := range size.H {
+			for x := range size.W {
+				p := firefly.P(x, y)
+				if opaque(img, transp, p) {
+					canvas.SetPixel(origin.Add(p).Add(offset), c)
+				}
+			}
+		}
+		copyOpaque(canvas, img, transp, origin)
+		return canvas
+	})
+}
+
+// The sprite with all non-transparent pixels of a single color.
+//
+// Use it with white color to make a sprite flash when it's hit.
+// Don't modify the returned canvas, it's shared by all calls with the same arguments.
+func Silhouette(img firefly.SubImage, c firefly.Color) firefly.Canvas {
+	return cached(key{kind: silhouette, color: c}, img, func() firefly.Canvas {
+		canvas, transp := newCanvas(img, img.Size(), c)
+		size := img.Size()
+		for y := range size.H {
+			for x := range size.W {
+				p := firefly.P(x, y)
+				if opaque(img, transp, p) {
+					canvas.SetPixel(p, c)
+				}
+			}
+		}
+		return canvas
+	})
+}
+
+// Get the canvas from the cache or generate and cache it.
+//
+// For an empty (not loaded) image, returns an empty canvas.
+func cached(k key, img firefly.SubImage, generate func() firefly.Canvas) firefly.Canvas {
+	raw := img.Image().Bytes()
+	if len(raw) == 0 {
+		return firefly.NewCanvas(firefly.Size{})
+	}
+	k.raw = &raw[0]
+	k.transp = img.Image().Transparency()
+	k.point = img.Point()
+	k.size = img.Size()
+	canvas, found := cache[k]
+	if !found {
+		canvas = generate()
+		cache[k] = canvas
+	}
+	return canvas
+}
+
+// Create a transparent canvas for the effect result.
+//
+// Also returns the transparent color of the source image.
+func newCanvas(img firefly.SubImage, s firefly.Size, c firefly.Color) (firefly.Canvas, firefly.Color) {
+	transp := img.Image().Transparency()
+	canvas := firefly.NewCanvas(s)
+	bg := transp
+	if bg == firefly.ColorNone || bg == c {
+		bg = unusedColor(img, c)
+	}
+	canvas.Fill(bg)
+	canvas.Image().SetTransparency(bg)
+	return canvas, transp
+}
+
+// Find a color not used by the image and different from the effect color.
+//
+// If all colors are used, returns a color different from the effect color anyway,
+// and some pixels of the sprite will be transparent in the result.
+func unusedColor(img firefly.SubImage, c firefly.Color) firefly.Color {
+	var used [17]bool
+	used[c] = true
+	size := img.Size()
+	for y := range size.H {
+		for x := range size.W {
+			used[img.GetPixel(firefly.P(x, y))] = true
+		}
+	}
+	for color := firefly.ColorBlack; color <= firefly.ColorDarkGray; color++ {
+		if !used[color] {
+			return color
+		}
+	}
+	if c == firefly.ColorBlack {
+		return firefly.ColorWhite
+	}
+	return firefly.ColorBlack
+}
+
+func opaque(img firefly.SubImage, transp firefly.Color, p firefly.Point) bool {
+	c := img.GetPixel(p)
+	return c != firefly.ColorNone && c != transp
+}
+
+// Copy all non-transparent pixels of the image into the canvas at the given point.
+func copyOpaque(canvas firefly.Canvas, img firefly.SubImage, transp firefly.Color, at firefly.Point) {
+	size := img.Size()
+	for y := range size.H {
+		for x := range size.W {
+			p := firefly.P(x, y)
+			if c := img.GetPixel(p); c != transp {
+				canvas.SetPixel(at.Add(p), c)
+			}
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,111 @@
+package spritefx_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/spritefx"
+)
+
+// A 3x3 sprite with a single red pixel in the center.
+func testSprite() firefly.SubImage {
+	canvas := firefly.NewCanvas(firefly.S(3, 3))
+	canvas.Fill(firefly.ColorWhite)
+	canvas.SetPixel(firefly.P(1, 1), firefly.ColorRed)
+	img := canvas.Image()
+	img.SetTransparency(firefly.ColorWhite)
+	return img.Sub(firefly.P(0, 0), img.Size())
+}
+
+type pixel struct {
+	point firefly.Point
+	want  firefly.Color
+}
+
+func checkPixels(t *testing.T, canvas firefly.Canvas, size firefly.Size, pixels []pixel) {
+	t.Helper()
+	if canvas.Size() != size {
+		t.Fatalf("want size %v, got %v", size, canvas.Size())
+	}
+	transp := canvas.Image().Transparency()
+	for _, p := range pixels {
+		got := canvas.GetPixel(p.point)
+		if got == transp {
+			got = firefly.ColorNone
+		}
+		if got != p.want {
+			t.Errorf("pixel %v: want %s, got %s", p.point, p.want, got)
+		}
+	}
+}
+
+func TestOutline(t *testing.T) { //nolint:paralleltest // the effects cache is not thread-safe
+	canvas := spritefx.Outline(testSprite(), firefly.ColorBlack)
+	checkPixels(t, canvas, firefly.S(5, 5), []pixel{
+		{firefly.P(2, 2), firefly.ColorRed},
+		{firefly.P(1, 2), firefly.ColorBlack},
+		{firefly.P(3, 2), firefly.ColorBlack},
+		{firefly.P(2, 1), firefly.ColorBlack},
+		{firefly.P(2, 3), firefly.ColorBlack},
+		{firefly.P(1, 1), firefly.ColorNone},
+		{firefly.P(0, 2), firefly.ColorNone},
+	})
+}
+
+func TestShadow(t *testing.T) { //nolint:paralleltest // the effects cache is not thread-safe
+	canvas := spritefx.Shadow(testSprite(), firefly.P(1, 1), firefly.ColorGray)
+	checkPixels(t, canvas, firefly.S(4, 4), []pixel{
+		{firefly.P(1, 1), firefly.ColorRed},
+		{firefly.P(2, 2), firefly.ColorGray},
+		{firefly.P(0, 0), firefly.ColorNone},
+	})
+	canvas = spritefx.Shadow(testSprite(), firefly.P(-1, 0), firefly.ColorGray)
+	checkPixels(t, canvas, firefly.S(4, 3), []pixel{
+		{firefly.P(2, 1), firefly.ColorRed},
+		{firefly.P(1, 1), firefly.ColorGray},
+		{firefly.P(3, 1), firefly.ColorNone},
+	})
+}
+
+func TestSilhouette(t *testing.T) { //nolint:paralleltest // the effects cache is not thread-safe
+	canvas := spritefx.Silhouette(testSprite(), firefly.ColorWhite)
+	checkPixels(t, canvas, firefly.S(3, 3), []pixel{
+		{firefly.P(1, 1), firefly.ColorWhite},
+		{firefly.P(0, 0), firefly.ColorNone},
+	})
+	// The sprite transparency color is the same as the effect color,
+	// so another color must be used for transparency.
+	if canvas.Image().Transparency() == firefly.ColorWhite {
+		t.Errorf("white must not be transparent")
+	}
+}
+
+func TestCache(t *testing.T) { //nolint:paralleltest // the effects cache is not thread-safe
+	sprite := testSprite()
+	a := spritefx.Silhouette(sprite, firefly.ColorWhite)
+	b := spritefx.Silhouette(sprite, firefly.ColorWhite)
+	if !a.Image().Eq(b.Image()) {
+		t.Errorf("the same effect must be cached")
+	}
+	c := spritefx.Silhouette(sprite, firefly.ColorBlack)
+	if a.Image().Eq(c.Image()) {
+		t.Errorf("effects with different colors must not be shared")
+	}
+}
+
+func TestEmptyImage(t *testing.T) { //nolint:paralleltest // the effects cache is not thread-safe
+	var sprite firefly.SubImage
+	if size := spritefx.Outline(sprite, firefly.ColorRed).Size(); size != (firefly.Size{}) {
+		t.Errorf("want an empty canvas, got %v", size)
+	}
+}
+
+func TestCache_Transparency(t *testing.T) { //nolint:paralleltest // the effects cache is not thread-safe
+	sprite := testSprite()
+	a := spritefx.Silhouette(sprite, firefly.ColorBlack)
+	sprite.Image().SetTransparency(firefly.ColorRed)
+	b := spritefx.Silhouette(sprite, firefly.ColorBlack)
+	if a.Image().Eq(b.Image()) {
+		t.Errorf("changing the source transparency must invalidate the cache")
+	}
+}
@@ -0,0 +1,108 @@
+package text
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// Break lines between words so that the text fits into the given width (in pixels).
+//
+// Explicit line breaks are preserved. Spaces between words stay at the end of the line.
+// Words longer than the width are not broken and stick out.
+func (s *Styled) Wrap(width int) {
+	charW := s.Font.CharWidth()
+	charH := s.Font.CharHeight()
+	if charW <= 0 || len(s.Glyphs) == 0 {
+		return
+	}
+	glyphs := make([]Glyph, 0, len(s.Glyphs))
+	x, y := 0, 0
+	prevY := s.Glyphs[0].Point.Y
+	for i, g := range s.Glyphs {
+		if g.Point.Y != prevY {
+			// An explicit line break (might be more than one).
+			y += g.Point.Y - prevY
+			prevY = g.Point.Y
+			x = 0
+		}
+		if s.isWordStart(i) && x > 0 && x+s.wordLen(i)*charW > width {
+			x = 0
+			y += charH
+		}
+		g.Point = firefly.P(x, y)
+		g.Index = len(glyphs)
+		glyphs = append(glyphs, g)
+		x += charW
+	}
+	s.Glyphs = glyphs
+	s.updateSize()
+}
+
+// Check if the glyph is the first letter of a word.
+func (s *Styled) isWordStart(i int) bool {
+	g := s.Glyphs[i]
+	if g.Rune == ' ' {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev := s.Glyphs[i-1]
+	return prev.Rune == ' ' || prev.Point.Y != g.Point.Y
+}
+
+// The number of glyphs in the word starting at the given glyph.
+func (s *Styled) wordLen(start int) int {
+	y := s.Glyphs[start].Point.Y
+	n := 0
+	for _, g := range s.Glyphs[start:] {
+		if g.Rune == ' ' || g.Point.Y != y {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// Recalculate the size of the text box from the glyph positions.
+func (s *Styled) updateSize() {
+	charW := s.Font.CharWidth()
+	charH := s.Font.CharHeight()
+	s.size = firefly.Size{}
+	for _, g := range s.Glyphs {
+		s.size.W = max(s.size.W, g.Point.X+charW)
+		s.size.H = max(s.size.H, g.Point.Y+charH)
+	}
+}
+
+// Split the text into pages that fit into the given height (in pixels).
+//
+// Every page is a separate [Styled] text starting at the top.
+// Glyph indices start from zero on every page.
+// Use together with [Styled.Wrap] to fit the text into a box.
+func (s *Styled) Paginate(height int) []*Styled {
+	charH := s.Font.CharHeight()
+	if charH <= 0 {
+		return []*Styled{s}
+	}
+	perPage := max(height/charH, 1)
+	var pages []*Styled
+	for _, g := range s.Glyphs {
+		line := g.Point.Y / charH
+		pageIdx := line / perPage
+		for len(pages) <= pageIdx {
+			page := *s
+			page.Glyphs = nil
+			pages = append(pages, &page)
+		}
+		page := pages[pageIdx]
+		g.Point.Y -= pageIdx * perPage * charH
+		g.Index = len(page.Glyphs)
+		page.Glyphs = append(page.Glyphs, g)
+	}
+	if len(pages) == 0 {
+		page := *s
+		return []*Styled{&page}
+	}
+	for _, page := range pages {
+		page.updateSize()
+	}
+	return pages
+}
@@ -0,0 +1,39 @@
+package text
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// Offsets of all 8 neighbors of a pixel.
+var neighbors = [8]firefly.Point{
+	{X: -1, Y: -1},
+	{X: 0, Y: -1},
+	{X: 1, Y: -1},
+	{X: -1, Y: 0},
+	{X: 1, Y: 0},
+	{X: -1, Y: 1},
+	{X: 0, Y: 1},
+	{X: 1, Y: 1},
+}
+
+// Render text with a one pixel outline around every character.
+//
+// Makes the text readable on top of any background.
+func DrawOutlined(t string, f firefly.Font, p firefly.Point, c, outline firefly.Color) {
+	for _, offset := range neighbors {
+		firefly.DrawText(t, f, p.Add(offset), outline)
+	}
+	firefly.DrawText(t, f, p, c)
+}
+
+// Render text with a drop shadow one pixel right and down.
+func DrawShadowed(t string, f firefly.Font, p firefly.Point, c, shadow firefly.Color) {
+	firefly.DrawText(t, f, p.Add(firefly.P(1, 1)), shadow)
+	firefly.DrawText(t, f, p, c)
+}
+
+func drawOutline(raw []byte, f firefly.Font, p firefly.Point, c firefly.Color) {
+	for _, offset := range neighbors {
+		firefly.DrawTextBytes(raw, f, p.Add(offset), c)
+	}
+}
@@ -0,0 +1,303 @@
+// Styled text with per-character colors and animations.
+//
+// The text is described using inline markup:
+//
+//	This is {red}danger{/}, and this is {wave}wavy {yellow}gold{/}{/}.
+//
+// Supported tags:
+//
+//   - Color names from the default palette in lower case: {red}, {lightblue}, etc.
+//   - {wave}: characters move up and down in a wave.
+//   - {shake}: characters randomly jitter.
+//   - {fade}: characters fade in one after another.
+//   - {/}: closes the last opened tag. Every opened tag must be closed.
+//
+// Use "{{" for a literal opening brace.
+package text
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+var (
+	ErrUnknownTag = errors.New("unknown markup tag")
+	ErrUnclosed   = errors.New("unclosed markup tag")
+	ErrUnopened   = errors.New("closing tag without an opening tag")
+)
+
+// Animation effects that can be applied to a glyph.
+//
+// Multiple effects can be combined.
+type Effect uint8
+
+const (
+	// Move characters up and down in a wave.
+	Wave Effect = 1 << iota
+	// Randomly jitter characters.
+	Shake
+	// Fade in characters one after another.
+	Fade
+)
+
+// A single character of a [Styled] text.
+type Glyph struct {
+	// The character to draw.
+	Rune rune
+
+	// The index of the glyph in the text, newlines are not counted.
+	Index int
+
+	// The position of the glyph relative to the text position.
+	//
+	// Like in [firefly.DrawText], it's the position of the baseline start.
+	Point firefly.Point
+
+	// The color of the glyph.
+	Color firefly.Color
+
+	// The animation effects applied to the glyph.
+	Effects Effect
+}
+
+// A text where every character has its own color and animation.
+//
+// Constructed by [Parse].
+type Styled struct {
+	// The font used to draw the text.
+	Font firefly.Font
+
+	// All visible characters of the text.
+	Glyphs []Glyph
+
+	// If not [firefly.ColorNone], every character has an outline of this color.
+	Outline firefly.Color
+
+	// If not [firefly.ColorNone], every character has a drop shadow of this color.
+	Shadow firefly.Color
+
+	// The offset of the drop shadow. The default is one pixel right and down.
+	ShadowOffset firefly.Point
+
+	// Colors that characters with the [Fade] effect go through before reaching their color.
+	//
+	// The default is dark gray, gray, and light gray.
+	FadeRamp []firefly.Color
+
+	size firefly.Size
+}
+
+type state struct {
+	color   firefly.Color
+	effects Effect
+}
+
+// Parse the markup and lay out the glyphs using the given font.
+//
+// The color is used for characters that don't have a color tag.
+func Parse(markup string, font firefly.Font, color firefly.Color) (*Styled, error) {
+	s := &Styled{Font: font}
+	stack := []state{{color: color}}
+	charW := font.CharWidth()
+	charH := font.CharHeight()
+	x, y, lines := 0, 0, 1
+	index := 0
+	for i := 0; i < len(markup); {
+		r, size := utf8.DecodeRuneInString(markup[i:])
+		i += size
+		if r == '{' {
+			if strings.HasPrefix(markup[i:], "{") {
+				i++
+			} else {
+				end := strings.IndexByte(markup[i:], '}')
+				if end < 0 {
+					return nil, fmt.Errorf("%w at %d", ErrUnclosed, i-1)
+				}
+				tag := markup[i : i+end]
+				i += end + 1
+				var err error
+				stack, err = applyTag(stack, tag)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		if r == '\n' {
+			x = 0
+			y += charH
+			lines++
+			continue
+		}
+		top := stack[len(stack)-1]
+		s.Glyphs = append(s.Glyphs, Glyph{
+			Rune:    r,
+			Index:   index,
+			Point:   firefly.P(x, y),
+			Color:   top.color,
+			Effects: top.effects,
+		})
+		index++
+		x += charW
+		s.size.W = max(s.size.W, x)
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("%w: %d tags left open", ErrUnclosed, len(stack)-1)
+	}
+	s.size.H = lines * charH
+	return s, nil
+}
+
+func applyTag(stack []state, tag string) ([]state, error) {
+	top := stack[len(stack)-1]
+	switch tag {
+	case "/":
+		if len(stack) == 1 {
+			return nil, ErrUnopened
+		}
+		return stack[:len(stack)-1], nil
+	case "wave":
+		top.effects |= Wave
+	case "shake":
+		top.effects |= Shake
+	case "fade":
+		top.effects |= Fade
+	default:
+		c, ok := colorByName(tag)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTag, tag)
+		}
+		top.color = c
+	}
+	return append(stack, top), nil
+}
+
+func colorByName(name string) (firefly.Color, bool) {
+	for c := firefly.ColorBlack; c <= firefly.ColorDarkGray; c++ {
+		if strings.ToLower(c.String()) == name {
+			return c, true
+		}
+	}
+	return firefly.ColorNone, false
+}
+
+// The number of glyphs in the text.
+func (s *Styled) Len() int {
+	return len(s.Glyphs)
+}
+
+// The size of the text box, without animations, outline, and shadow.
+func (s *Styled) Size() firefly.Size {
+	return s.size
+}
+
+// Render the text.
+//
+// The point is the baseline start, like in [firefly.DrawText].
+// The frame is the number of frames passed since the text appeared,
+// it drives the animations.
+func (s *Styled) Draw(p firefly.Point, frame int) {
+	s.DrawN(p, frame, len(s.Glyphs))
+}
+
+// Render only the first n glyphs of the text.
+//
+// Useful for revealing the text one character at a time.
+func (s *Styled) DrawN(p firefly.Point, frame, n int) {
+	glyphs := s.Glyphs[:min(max(n, 0), len(s.Glyphs))]
+	// Shadows and outlines of all glyphs are drawn first,
+	// so that the outline of a glyph doesn't cover the previous glyph.
+	if s.Shadow != firefly.ColorNone || s.Outline != firefly.ColorNone {
+		for _, g := range glyphs {
+			s.drawGlyph(p, g, frame, true)
+		}
+	}
+	for _, g := range glyphs {
+		s.drawGlyph(p, g, frame, false)
+	}
+}
+
+// Render the shadow and the outline of the glyph or the glyph itself.
+func (s *Styled) drawGlyph(p firefly.Point, g Glyph, frame int, back bool) {
+	color := s.GlyphColor(g, frame)
+	if color == firefly.ColorNone || g.Rune == ' ' {
+		return
+	}
+	var buf [utf8.UTFMax]byte
+	raw := utf8.AppendRune(buf[:0], g.Rune)
+	point := p.Add(g.Point).Add(s.GlyphOffset(g, frame))
+	if !back {
+		firefly.DrawTextBytes(raw, s.Font, point, color)
+		return
+	}
+	if s.Shadow != firefly.ColorNone {
+		offset := s.ShadowOffset
+		if offset == (firefly.Point{}) {
+			offset = firefly.P(1, 1)
+		}
+		firefly.DrawTextBytes(raw, s.Font, point.Add(offset), s.Shadow)
+	}
+	if s.Outline != firefly.ColorNone {
+		drawOutline(raw, s.Font, point, s.Outline)
+	}
+}
+
+const (
+	waveAmplitude = 2
+	wavePeriod    = 40 // frames
+	waveLength    = 8  // glyphs
+	shakeRate     = 3  // frames
+	fadeDelay     = 2  // frames
+	fadeStep      = 3  // frames
+)
+
+// The offset of the glyph caused by its animation effects on the given frame.
+func (s *Styled) GlyphOffset(g Glyph, frame int) firefly.Point {
+	var offset firefly.Point
+	if g.Effects&Wave != 0 {
+		t := float32(frame)/wavePeriod - float32(g.Index)/waveLength
+		offset.Y += int(tinymath.Round(waveAmplitude * tinymath.Sin(2*math.Pi*t)))
+	}
+	if g.Effects&Shake != 0 {
+		// Cheap deterministic noise, so that there are no host calls for random.
+		h := uint32(g.Index)*73856093 ^ uint32(frame/shakeRate)*19349663
+		h ^= h >> 13
+		offset.X += int(h%3) - 1
+		offset.Y += int(h/3%3) - 1
+	}
+	return offset
+}
+
+var defaultFadeRamp = []firefly.Color{
+	firefly.ColorDarkGray,
+	firefly.ColorGray,
+	firefly.ColorLightGray,
+}
+
+// The color of the glyph on the given frame.
+//
+// Returns [firefly.ColorNone] if the glyph is not visible yet.
+func (s *Styled) GlyphColor(g Glyph, frame int) firefly.Color {
+	if g.Effects&Fade == 0 {
+		return g.Color
+	}
+	age := frame - g.Index*fadeDelay
+	if age < 0 {
+		return firefly.ColorNone
+	}
+	ramp := s.FadeRamp
+	if ramp == nil {
+		ramp = defaultFadeRamp
+	}
+	step := age / fadeStep
+	if step < len(ramp) {
+		return ramp[step]
+	}
+	return g.Color
+}
@@ -0,0 +1,107 @@
+package text_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/text"
+)
+
+// A font header with 6x10 glyphs and no glyph data.
+var testFont = firefly.UnsafeFileFromBytes([]byte{0x11, 0, 6, 10}).Font()
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	s, err := text.Parse("a{red}b{wave}c{/}d{/}e\n{{f", testFont, firefly.ColorWhite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []text.Glyph{
+		{Rune: 'a', Index: 0, Point: firefly.P(0, 0), Color: firefly.ColorWhite},
+		{Rune: 'b', Index: 1, Point: firefly.P(6, 0), Color: firefly.ColorRed},
+		{Rune: 'c', Index: 2, Point: firefly.P(12, 0), Color: firefly.ColorRed, Effects: text.Wave},
+		{Rune: 'd', Index: 3, Point: firefly.P(18, 0), Color: firefly.ColorRed},
+		{Rune: 'e', Index: 4, Point: firefly.P(24, 0), Color: firefly.ColorWhite},
+		{Rune: '{', Index: 5, Point: firefly.P(0, 10), Color: firefly.ColorWhite},
+		{Rune: 'f', Index: 6, Point: firefly.P(6, 10), Color: firefly.ColorWhite},
+	}
+	if len(s.Glyphs) != len(want) {
+		t.Fatalf("want %d glyphs, but got %d", len(want), len(s.Glyphs))
+	}
+	for i, g := range s.Glyphs {
+		if g != want[i] {
+			t.Errorf("glyph %d: want %v, but got %v", i, want[i], g)
+		}
+	}
+	if s.Size() != firefly.S(30, 20) {
+		t.Errorf("want size 30x20, but got %v", s.Size())
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		markup string
+		want   error
+	}{
+		{markup: "{blink}hi{/}", want: text.ErrUnknownTag},
+		{markup: "{red hi", want: text.ErrUnclosed},
+		{markup: "{red}hi", want: text.ErrUnclosed},
+		{markup: "{red}{wave}hi{/}", want: text.ErrUnclosed},
+		{markup: "hi{/}", want: text.ErrUnopened},
+	}
+	for _, test := range tests {
+		_, err := text.Parse(test.markup, testFont, firefly.ColorWhite)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%q: want %v, but got %v", test.markup, test.want, err)
+		}
+	}
+}
+
+func TestStyled_GlyphColor(t *testing.T) {
+	t.Parallel()
+	s, err := text.Parse("{fade}ab{/}", testFont, firefly.ColorWhite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second := s.Glyphs[1]
+	if s.GlyphColor(second, 0) != firefly.ColorNone {
+		t.Errorf("the second glyph must be invisible on the first frame")
+	}
+	if s.GlyphColor(second, 2) != firefly.ColorDarkGray {
+		t.Errorf("the second glyph must start fading in")
+	}
+	if s.GlyphColor(second, 100) != firefly.ColorWhite {
+		t.Errorf("the second glyph must eventually have its color")
+	}
+}
+
+func TestStyled_Wrap(t *testing.T) {
+	t.Parallel()
+	s, err := text.Parse("one two three\nfour", testFont, firefly.ColorWhite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 8 characters per line.
+	s.Wrap(6 * 8)
+	lines := make(map[int]string)
+	for _, g := range s.Glyphs {
+		lines[g.Point.Y/10] += string(g.Rune)
+	}
+	want := []string{"one two ", "three", "four"}
+	for i, w := range want {
+		if lines[i] != w {
+			t.Errorf("line %d: want %q, but got %q", i, w, lines[i])
+		}
+	}
+
+	pages := s.Paginate(20)
+	if len(pages) != 2 {
+		t.Fatalf("want 2 pages, but got %d", len(pages))
+	}
+	first := pages[1].Glyphs[0]
+	if first.Rune != 'f' || first.Index != 0 || first.Point != firefly.P(0, 0) {
+		t.Errorf("the second page must start from the top, got %v", first)
+	}
+}
@@ -0,0 +1,312 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+	"github.com/orsinium-labs/tinymath"
+)
+
+// How the touch pad moves the [Cursor].
+type CursorMode uint8
+
+const (
+	// Every point of the pad corresponds to a point on the screen, see [PadToScreen].
+	CursorAbsolute CursorMode = iota
+	// Moving the finger over the pad moves the cursor, like on a laptop trackpad.
+	CursorRelative
+)
+
+// A hit region for the [Cursor].
+//
+// Implemented by [Rect] and by all [shapes.Shape] types.
+type Region interface {
+	Contains(p firefly.Point) bool
+}
+
+// The kind of a [CursorEvent].
+type CursorEventKind uint8
+
+const (
+	// The cursor moved over the region.
+	Enter CursorEventKind = iota + 1
+	// The cursor moved out of the region.
+	Leave
+	// The click button is pressed over the region.
+	Press
+	// The click button is released. The region is the one that was pressed.
+	Release
+	// The click button is pressed and then released over the same region.
+	Click
+)
+
+// A cursor interaction with a region.
+type CursorEvent struct {
+	Kind CursorEventKind
+
+	// The index of the region, see [Cursor.Add].
+	Region int
+
+	// The cursor position when the event happened.
+	Point firefly.Point
+}
+
+// An on-screen pointer controlled by the touch pad.
+//
+// Useful for point-and-click games. The [firefly.Buttons].S is the click button.
+//
+// Constructed by [NewCursor].
+type Cursor struct {
+	Mode CursorMode
+
+	// The cursor position on the screen.
+	Point firefly.Point
+
+	// The area the cursor can't leave. Defaults to the whole screen.
+	Bounds Rect
+
+	// In the relative mode, how many pixels the cursor moves
+	// when the finger moves across the whole pad.
+	Speed float32
+
+	// In the relative mode, how much faster the cursor moves when the finger moves fast.
+	//
+	// Zero means the cursor speed doesn't depend on the finger speed.
+	// With 1, moving the finger by a tenth of the pad in one frame doubles the speed.
+	Acceleration float32
+
+	// The shape drawn at the cursor position, like [shapes.Image].
+	//
+	// The shape position is relative to the cursor hotspot,
+	// so an image with the hotspot in its center should have
+	// the point at minus half of its size.
+	// If nil, a small cross is drawn.
+	Sprite shapes.Shape
+
+	// The color of the default cross if [Cursor.Sprite] is nil.
+	Color firefly.Color
+
+	// Hit regions to report events for.
+	//
+	// If regions overlap, the one added later is on top.
+	// For cursors created by [Cursors], these are the regions of this cursor only,
+	// and they are on top of the shared regions.
+	Regions []Region
+
+	// The regions shared by all [Cursors].
+	shared *[]Region
+
+	hover   int
+	pressed int
+	clicked bool
+	touched bool
+	prevPad firefly.Pad
+	// Fractional pixels left from relative movements.
+	restX float32
+	restY float32
+}
+
+// Create a cursor in the center of the screen.
+func NewCursor(mode CursorMode) *Cursor {
+	bounds := R(0, 0, firefly.Width, firefly.Height)
+	return &Cursor{
+		Mode:         mode,
+		Point:        bounds.Center(),
+		Bounds:       bounds,
+		Speed:        firefly.Width,
+		Acceleration: 1,
+		Color:        firefly.ColorBlack,
+		hover:        -1,
+		pressed:      -1,
+	}
+}
+
+// Add hit regions and return the index of the first added one.
+//
+// For cursors created by [Cursors], the shared regions come first,
+// so adding more shared regions shifts the indices of the cursor's own regions.
+func (c *Cursor) Add(regions ...Region) int {
+	c.Regions = append(c.Regions, regions...)
+	return c.sharedLen() + len(c.Regions) - len(regions)
+}
+
+// The number of regions shared with other [Cursors].
+func (c *Cursor) sharedLen() int {
+	if c.shared == nil {
+		return 0
+	}
+	return len(*c.shared)
+}
+
+// The index of the region under the cursor or -1.
+func (c *Cursor) Hover() int {
+	return c.hover
+}
+
+// Process the input and return the events for the regions.
+//
+// The pad and the buttons are usually from [firefly.ReadPad] and [firefly.ReadButtons].
+// If the pad isn't touched, pass false as touched.
+func (c *Cursor) Update(pad firefly.Pad, touched bool, buttons firefly.Buttons) []CursorEvent {
+	if touched {
+		switch c.Mode {
+		case CursorAbsolute:
+			c.Point = PadToScreen(pad)
+		case CursorRelative:
+			if c.touched {
+				c.move(pad.X-c.prevPad.X, c.prevPad.Y-pad.Y)
+			}
+		}
+	}
+	c.Point = c.clamp(c.Point)
+	c.touched = touched
+	c.prevPad = pad
+
+	var events []CursorEvent
+	hover := c.HitTest(c.Point)
+	if hover != c.hover {
+		if c.hover >= 0 {
+			events = append(events, c.event(Leave, c.hover))
+		}
+		if hover >= 0 {
+			events = append(events, c.event(Enter, hover))
+		}
+		c.hover = hover
+	}
+	switch {
+	case buttons.S && !c.clicked:
+		c.pressed = hover
+		if hover >= 0 {
+			events = append(events, c.event(Press, hover))
+		}
+	case !buttons.S && c.clicked:
+		if c.pressed >= 0 {
+			events = append(events, c.event(Release, c.pressed))
+			if c.pressed == hover {
+				events = append(events, c.event(Click, hover))
+			}
+		}
+		c.pressed = -1
+	}
+	c.clicked = buttons.S
+	return events
+}
+
+// The index of the topmost region containing the point or -1.
+func (c *Cursor) HitTest(p firefly.Point) int {
+	shared := c.sharedLen()
+	for i := len(c.Regions) - 1; i >= 0; i-- {
+		if c.Regions[i].Contains(p) {
+			return shared + i
+		}
+	}
+	for i := shared - 1; i >= 0; i-- {
+		if (*c.shared)[i].Contains(p) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Render the cursor.
+func (c *Cursor) Draw() {
+	if c.Sprite != nil {
+		c.Sprite.DrawAt(c.Point)
+		return
+	}
+	drawPointer(c.Point, c.Color)
+}
+
+func (c *Cursor) event(kind CursorEventKind, region int) CursorEvent {
+	return CursorEvent{Kind: kind, Region: region, Point: c.Point}
+}
+
+// Move the cursor by the pad delta in the relative mode.
+func (c *Cursor) move(dx, dy int) {
+	scale := c.Speed / (firefly.PadMaxX - firefly.PadMinX)
+	if c.Acceleration != 0 {
+		// The finger speed as a fraction of the pad size per frame.
+		speed := tinymath.Hypot(float32(dx), float32(dy)) / (firefly.PadMaxX - firefly.PadMinX)
+		scale *= 1 + c.Acceleration*speed*10
+	}
+	x := float32(dx)*scale + c.restX
+	y := float32(dy)*scale + c.restY
+	mx := int(x)
+	my := int(y)
+	c.restX = x - float32(mx)
+	c.restY = y - float32(my)
+	c.Point = c.Point.Add(firefly.P(mx, my))
+}
+
+func (c *Cursor) clamp(p firefly.Point) firefly.Point {
+	b := c.Bounds
+	p.X = min(max(p.X, b.X), b.X+b.W-1)
+	p.Y = min(max(p.Y, b.Y), b.Y+b.H-1)
+	return p
+}
+
+// A cursor for each peer, for multiplayer point-and-click games.
+//
+// Constructed by [NewCursors].
+type Cursors struct {
+	// The cursor that is copied for every new peer.
+	//
+	// Its hit regions are ignored, see [Cursors.Add].
+	Template Cursor
+
+	regions []Region
+	cursors map[firefly.Peer]*Cursor
+	peers   []firefly.Peer
+}
+
+// Create per-peer cursors that are copies of the given cursor.
+//
+// The hit regions of the given cursor are shared by all cursors.
+func NewCursors(template *Cursor) *Cursors {
+	cs := &Cursors{
+		Template: *template,
+		regions:  slices.Clone(template.Regions),
+		cursors:  make(map[firefly.Peer]*Cursor),
+	}
+	cs.Template.Regions = nil
+	return cs
+}
+
+// Add hit regions to all cursors and return the index of the first added one.
+//
+// Regions added with [Cursor.Add] to a single cursor are seen only by that cursor.
+func (cs *Cursors) Add(regions ...Region) int {
+	cs.regions = append(cs.regions, regions...)
+	return len(cs.regions) - len(regions)
+}
+
+// The cursor of the peer.
+//
+// The cursor for a peer that hasn't been seen yet is created on the first call.
+func (cs *Cursors) Get(p firefly.Peer) *Cursor {
+	c, found := cs.cursors[p]
+	if !found {
+		template := cs.Template
+		c = &template
+		c.shared = &cs.regions
+		cs.cursors[p] = c
+		cs.peers = append(cs.peers, p)
+	}
+	return c
+}
+
+// Forget the cursor of the peer, for example, when the peer goes offline.
+func (cs *Cursors) Remove(p firefly.Peer) {
+	delete(cs.cursors, p)
+	cs.peers = slices.DeleteFunc(cs.peers, func(peer firefly.Peer) bool {
+		return peer == p
+	})
+}
+
+// Render all cursors in the order the peers were added.
+func (cs *Cursors) Draw() {
+	for _, p := range cs.peers {
+		cs.cursors[p].Draw()
+	}
+}
@@ -0,0 +1,139 @@
+package ui_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/shapes"
+	"github.com/firefly-zero/firefly-go/firefly/ui"
+)
+
+func TestCursor_Absolute(t *testing.T) {
+	t.Parallel()
+	c := ui.NewCursor(ui.CursorAbsolute)
+	c.Update(firefly.Pad{X: -1000, Y: 1000}, true, firefly.Buttons{})
+	if c.Point != firefly.P(0, 0) {
+		t.Errorf("want top-left corner, got %v", c.Point)
+	}
+	c.Bounds = ui.R(10, 10, 100, 100)
+	c.Update(firefly.Pad{X: 1000, Y: -1000}, true, firefly.Buttons{})
+	if c.Point != firefly.P(109, 109) {
+		t.Errorf("the cursor must stay in bounds, got %v", c.Point)
+	}
+	// Releasing the pad doesn't move the cursor.
+	c.Update(firefly.Pad{}, false, firefly.Buttons{})
+	if c.Point != firefly.P(109, 109) {
+		t.Errorf("the cursor must stay in place, got %v", c.Point)
+	}
+}
+
+func TestCursor_Relative(t *testing.T) {
+	t.Parallel()
+	c := ui.NewCursor(ui.CursorRelative)
+	c.Acceleration = 0
+	start := c.Point
+	c.Update(firefly.Pad{X: 500, Y: 500}, true, firefly.Buttons{})
+	if c.Point != start {
+		t.Errorf("touching the pad must not move the cursor, got %v", c.Point)
+	}
+	c.Update(firefly.Pad{X: 600, Y: 600}, true, firefly.Buttons{})
+	if want := start.Add(firefly.P(12, -12)); c.Point != want {
+		t.Errorf("want %v, got %v", want, c.Point)
+	}
+	// Lifting the finger and touching another place doesn't move the cursor.
+	c.Update(firefly.Pad{}, false, firefly.Buttons{})
+	c.Update(firefly.Pad{X: -900}, true, firefly.Buttons{})
+	if want := start.Add(firefly.P(12, -12)); c.Point != want {
+		t.Errorf("want %v, got %v", want, c.Point)
+	}
+
+	fast := ui.NewCursor(ui.CursorRelative)
+	fast.Update(firefly.Pad{X: 0}, true, firefly.Buttons{})
+	fast.Update(firefly.Pad{X: 200}, true, firefly.Buttons{})
+	if fast.Point.X-start.X <= 24 {
+		t.Errorf("acceleration must make the cursor move faster, got %v", fast.Point)
+	}
+}
+
+func TestCursor_Events(t *testing.T) {
+	t.Parallel()
+	c := ui.NewCursor(ui.CursorAbsolute)
+	c.Add(
+		ui.R(0, 0, 120, 160),
+		shapes.Circle{Point: firefly.P(0, 0), Diameter: 40},
+	)
+	kinds := func(events []ui.CursorEvent) []ui.CursorEventKind {
+		var result []ui.CursorEventKind
+		for _, e := range events {
+			result = append(result, e.Kind)
+		}
+		return result
+	}
+	steps := []struct {
+		pad     firefly.Pad
+		buttons firefly.Buttons
+		want    []ui.CursorEventKind
+		hover   int
+	}{
+		{firefly.Pad{X: -500}, firefly.Buttons{}, []ui.CursorEventKind{ui.Enter}, 0},
+		{firefly.Pad{X: -500}, firefly.Buttons{S: true}, []ui.CursorEventKind{ui.Press}, 0},
+		{firefly.Pad{X: -500}, firefly.Buttons{}, []ui.CursorEventKind{ui.Release, ui.Click}, 0},
+		{firefly.Pad{X: -830, Y: 750}, firefly.Buttons{S: true}, []ui.CursorEventKind{ui.Leave, ui.Enter, ui.Press}, 1},
+		{firefly.Pad{X: 500}, firefly.Buttons{}, []ui.CursorEventKind{ui.Leave, ui.Release}, -1},
+	}
+	for i, s := range steps {
+		got := kinds(c.Update(s.pad, true, s.buttons))
+		if !slices.Equal(got, s.want) {
+			t.Errorf("step %d: want %v, got %v", i, s.want, got)
+		}
+		if c.Hover() != s.hover {
+			t.Errorf("step %d: want hover %d, got %d", i, s.hover, c.Hover())
+		}
+	}
+}
+
+func TestCursors(t *testing.T) {
+	t.Parallel()
+	cs := ui.NewCursors(ui.NewCursor(ui.CursorAbsolute))
+	a := cs.Get(firefly.Combined)
+	b := cs.Get(firefly.Peer{})
+	if a == b || cs.Get(firefly.Combined) != a {
+		t.Fatalf("each peer must have its own cursor")
+	}
+	a.Update(firefly.Pad{X: -1000, Y: 1000}, true, firefly.Buttons{})
+	if b.Point == a.Point {
+		t.Errorf("cursors must move independently")
+	}
+}
+
+func TestCursors_Regions(t *testing.T) {
+	t.Parallel()
+	template := ui.NewCursor(ui.CursorAbsolute)
+	template.Add(ui.R(0, 0, 10, 10))
+	cs := ui.NewCursors(template)
+	a := cs.Get(firefly.Combined)
+	b := cs.Get(firefly.Peer{})
+	if i := cs.Add(ui.R(20, 0, 10, 10)); i != 1 {
+		t.Errorf("want index 1, got %d", i)
+	}
+	if a.HitTest(firefly.P(25, 5)) != 1 || b.HitTest(firefly.P(25, 5)) != 1 {
+		t.Errorf("shared regions must be seen by all cursors")
+	}
+	if i := a.Add(ui.R(40, 0, 10, 10)); i != 2 {
+		t.Errorf("want index 2, got %d", i)
+	}
+	b.Add(ui.R(60, 0, 10, 10))
+	if a.HitTest(firefly.P(45, 5)) != 2 || a.HitTest(firefly.P(65, 5)) != -1 {
+		t.Errorf("regions added to one cursor must not be seen by another")
+	}
+
+	// A shared region added after a region of a single cursor.
+	cs.Add(ui.R(80, 0, 10, 10))
+	if a.HitTest(firefly.P(85, 5)) != 2 || b.HitTest(firefly.P(85, 5)) != 2 {
+		t.Errorf("shared regions must be seen by all cursors")
+	}
+	if a.HitTest(firefly.P(45, 5)) != 3 || b.HitTest(firefly.P(65, 5)) != 3 {
+		t.Errorf("regions of a single cursor must be kept")
+	}
+}
@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/text"
+)
+
+// A single line of a conversation.
+type Line struct {
+	// The name of the character saying the line. Optional.
+	Speaker string
+
+	// The picture of the character. Optional.
+	//
+	// Shown on the left side of the box. For example, from [firefly.Atlas.Named].
+	Portrait firefly.Sprite
+
+	// The text of the line.
+	//
+	// Can contain markup supported by the [text] package.
+	Text string
+}
+
+type queued struct {
+	line  Line
+	pages []*text.Styled
+}
+
+// A box that shows dialogue lines, revealing them character by character.
+//
+// Text that doesn't fit into the box is split into pages.
+// The player presses [firefly.Buttons].S to reveal the whole page
+// or to go to the next page, and [firefly.Buttons].E to skip the whole conversation.
+//
+// Constructed by [NewDialogue].
+type Dialogue struct {
+	// The nine-slice frame of the box, see [firefly.DrawNineSlice].
+	//
+	// If the size is zero, the box is drawn as a rectangle using [Dialogue.Style].
+	Frame firefly.SubImage
+
+	// The style of the box if there is no [Dialogue.Frame].
+	Style firefly.Style
+
+	// The position of the box on the screen.
+	Point firefly.Point
+
+	// The size of the box.
+	Size firefly.Size
+
+	// The space between the box border and the content.
+	Padding int
+
+	// The font for the text and the speaker name.
+	Font firefly.Font
+
+	// The default color of the text.
+	Color firefly.Color
+
+	// The color of the speaker name.
+	NameColor firefly.Color
+
+	// How many frames to wait before revealing the next character.
+	//
+	// If zero, the whole page is shown at once.
+	CharDelay int
+
+	// Called every time a character is revealed.
+	//
+	// Use it to play a "blip" sound. Not called for spaces.
+	OnChar func(r rune)
+
+	// Called when the last line is closed or skipped.
+	OnDone func()
+
+	queue []queued
+	page  int
+	shown int
+	ticks int
+	frame int
+	prev  firefly.Buttons
+}
+
+// Create a dialogue box at the bottom of the screen.
+func NewDialogue(font firefly.Font) *Dialogue {
+	const height = 48
+	return &Dialogue{
+		Style:     firefly.Style{FillColor: firefly.ColorWhite, StrokeColor: firefly.ColorBlack, StrokeWidth: 1},
+		Point:     firefly.P(0, firefly.Height-height),
+		Size:      firefly.S(firefly.Width, height),
+		Padding:   4,
+		Font:      font,
+		Color:     firefly.ColorBlack,
+		NameColor: firefly.ColorBlue,
+		CharDelay: 2,
+	}
+}
+
+// Add lines to the conversation.
+//
+// If the markup of one of the lines is invalid, returns an error
+// and none of the lines are added. The layout is computed right away,
+// so set the box size and the font before adding lines.
+func (d *Dialogue) Say(lines ...Line) error {
+	items := make([]queued, 0, len(lines))
+	for _, line := range lines {
+		styled, err := text.Parse(line.Text, d.Font, d.Color)
+		if err != nil {
+			return err
+		}
+		area := d.textArea(line)
+		styled.Wrap(area.W)
+		items = append(items, queued{line: line, pages: styled.Paginate(area.H)})
+	}
+	wasActive := d.Active()
+	d.queue = append(d.queue, items...)
+	if !wasActive {
+		d.page = 0
+		d.startPage()
+	}
+	return nil
+}
+
+// Check if there is a line being shown.
+func (d *Dialogue) Active() bool {
+	return len(d.queue) > 0
+}
+
+// Check if the current page is fully revealed and the box waits for the player.
+func (d *Dialogue) Waiting() bool {
+	if !d.Active() {
+		return false
+	}
+	return d.shown >= d.currentPage().Len()
+}
+
+// The line currently being shown.
+func (d *Dialogue) Current() (Line, bool) {
+	if !d.Active() {
+		return Line{}, false
+	}
+	return d.queue[0].line, true
+}
+
+// Remove all lines.
+func (d *Dialogue) Skip() {
+	wasActive := d.Active()
+	d.queue = nil
+	if wasActive && d.OnDone != nil {
+		d.OnDone()
+	}
+}
+
+// Reveal all characters of the current page.
+func (d *Dialogue) RevealAll() {
+	if !d.Active() {
+		return
+	}
+	d.shown = d.currentPage().Len()
+}
+
+// Go to the next page or line.
+func (d *Dialogue) Advance() {
+	if !d.Active() {
+		return
+	}
+	d.page++
+	if d.page >= len(d.queue[0].pages) {
+		d.page = 0
+		d.queue = d.queue[1:]
+		if !d.Active() {
+			if d.OnDone != nil {
+				d.OnDone()
+			}
+			return
+		}
+	}
+	d.startPage()
+}
+
+// Update the state of the box.
+//
+// Should be called on every update with the current state of the buttons,
+// usually from [firefly.ReadButtons].
+func (d *Dialogue) Update(buttons firefly.Buttons) {
+	pressed := buttons.JustPressed(d.prev)
+	d.prev = buttons
+	if !d.Active() {
+		return
+	}
+	if pressed.E {
+		d.Skip()
+		return
+	}
+	if pressed.S {
+		if d.Waiting() {
+			d.Advance()
+		} else {
+			d.RevealAll()
+		}
+		return
+	}
+	d.frame++
+	d.reveal()
+}
+
+// Reveal the next characters if it's time to.
+func (d *Dialogue) reveal() {
+	page := d.currentPage()
+	if d.CharDelay <= 0 {
+		d.shown = page.Len()
+		return
+	}
+	d.ticks++
+	for d.ticks >= d.CharDelay && d.shown < page.Len() {
+		d.ticks -= d.CharDelay
+		r := page.Glyphs[d.shown].Rune
+		d.shown++
+		if d.OnChar != nil && r != ' ' {
+			d.OnChar(r)
+		}
+	}
+}
+
+func (d *Dialogue) startPage() {
+	d.shown = 0
+	d.ticks = 0
+	d.frame = 0
+}
+
+func (d *Dialogue) currentPage() *text.Styled {
+	return d.queue[0].pages[d.page]
+}
+
+// The area (relative to the box) where the text of the line is placed.
+func (d *Dialogue) textArea(line Line) Rect {
+	area := Rect{
+		Point: firefly.P(d.Padding, d.Padding),
+		Size:  d.Size.Sub(firefly.S(d.Padding*2, d.Padding*2)),
+	}
+	if line.Portrait.Exists() {
+		w := line.Portrait.Size().W + d.Padding
+		area.X += w
+		area.W -= w
+	}
+	if line.Speaker != "" {
+		h := d.Font.CharHeight()
+		area.Y += h
+		area.H -= h
+	}
+	return area
+}
+
+// Render the box.
+func (d *Dialogue) Draw() {
+	if !d.Active() {
+		return
+	}
+	if d.Frame.Width() > 0 {
+		firefly.DrawNineSlice(d.Frame, d.Point, d.Size)
+	} else {
+		firefly.DrawRect(d.Point, d.Size, d.Style)
+	}
+	line := d.queue[0].line
+	if line.Portrait.Exists() {
+		line.Portrait.Draw(d.Point.Add(firefly.P(d.Padding, d.Padding)))
+	}
+	area := d.textArea(line)
+	charH := d.Font.CharHeight()
+	if line.Speaker != "" {
+		name := d.Point.Add(firefly.P(area.X, d.Padding+charH))
+		firefly.DrawText(line.Speaker, d.Font, name, d.NameColor)
+	}
+	baseline := d.Point.Add(area.Point).Add(firefly.P(0, charH))
+	d.currentPage().DrawN(baseline, d.frame, d.shown)
+}
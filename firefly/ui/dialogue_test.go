@@ -0,0 +1,61 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/ui"
+)
+
+// A font header with 6x10 glyphs and no glyph data.
+var testFont = firefly.UnsafeFileFromBytes([]byte{0x11, 0, 6, 10}).Font()
+
+func TestDialogue(t *testing.T) {
+	t.Parallel()
+	d := ui.NewDialogue(testFont)
+	d.CharDelay = 1
+	d.Size = firefly.S(6*5+8, 10*2+8)
+	var revealed []rune
+	d.OnChar = func(r rune) { revealed = append(revealed, r) }
+	done := false
+	d.OnDone = func() { done = true }
+
+	err := d.Say(ui.Line{Text: "one two three four"}, ui.Line{Text: "bye"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	none := firefly.Buttons{}
+	press := firefly.Buttons{S: true}
+
+	d.Update(none)
+	d.Update(none)
+	if string(revealed) != "on" {
+		t.Errorf("want two revealed chars, but got %q", string(revealed))
+	}
+
+	// The first press reveals the page, the second goes to the next page.
+	d.Update(press)
+	if !d.Waiting() {
+		t.Errorf("the page must be fully revealed")
+	}
+	d.Update(none)
+	d.Update(press)
+	d.Update(none)
+	if d.Waiting() {
+		t.Errorf("the second page must start revealing")
+	}
+
+	// The third page is the next line.
+	d.Update(press)
+	d.Update(none)
+	d.Update(press)
+	line, _ := d.Current()
+	if line.Text != "bye" {
+		t.Errorf("want the second line, but got %q", line.Text)
+	}
+
+	d.Update(firefly.Buttons{E: true})
+	if d.Active() || !done {
+		t.Errorf("E must skip the conversation")
+	}
+}
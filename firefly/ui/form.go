@@ -0,0 +1,290 @@
+package ui
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The kind of an input [Event].
+type EventKind uint8
+
+const (
+	// The main action button ([firefly.Buttons].S) is pressed.
+	Activate EventKind = iota + 1
+	// The cancellation button ([firefly.Buttons].E) is pressed.
+	Back
+	Left
+	Right
+	Up
+	Down
+	// The pad is touched in the pointer mode. The point is the pointer position.
+	Touch
+)
+
+// An input event sent to the focused widget.
+type Event struct {
+	Kind EventKind
+
+	// The pointer position on the screen for [Touch] events.
+	Point firefly.Point
+
+	// For [Touch] events, true if the pad has just been touched.
+	//
+	// [Touch] events are sent on every update while the pad is touched,
+	// so buttons react only to the first one.
+	Start bool
+}
+
+// A UI element.
+//
+// Widgets don't read input directly. Instead, [Form] sends events
+// to the focused widget and handles focus navigation.
+type Widget interface {
+	// The area occupied by the widget.
+	Bounds() Rect
+
+	// Check if the widget can receive focus and input events.
+	Focusable() bool
+
+	// Handle the input event. Called only if the widget is focused.
+	//
+	// Returns false if the event wasn't handled by the widget.
+	// For example, if a list is scrolled to the end and the player
+	// presses down, the focus should move to the next widget.
+	Handle(e Event) bool
+
+	// Render the widget.
+	Draw(t Theme, focused bool)
+}
+
+// A collection of widgets with a focus.
+//
+// Constructed by [NewForm].
+type Form struct {
+	// Colors and the font for all widgets.
+	Theme Theme
+
+	// If true, the touch pad works as a pointer: the widget under the pointer
+	// gets the focus and touching it sends a [Touch] event.
+	// Otherwise, the pad works as an 8-directional D-pad for moving the focus,
+	// and pressing a diagonal moves the focus along both axes.
+	Pointer bool
+
+	widgets []Widget
+	focus   int
+	modal   *Modal
+	cursor  firefly.Point
+	touched bool
+	prevPad firefly.DPad8
+	prevBtn firefly.Buttons
+}
+
+// Create an empty form.
+func NewForm(theme Theme) *Form {
+	return &Form{Theme: theme, focus: -1}
+}
+
+// Add widgets to the form.
+//
+// The first focusable widget gets the focus.
+func (f *Form) Add(widgets ...Widget) {
+	f.widgets = append(f.widgets, widgets...)
+	if f.focus < 0 {
+		f.focusFirst()
+	}
+}
+
+// Remove all widgets.
+func (f *Form) Clear() {
+	f.widgets = nil
+	f.focus = -1
+}
+
+// The index of the focused widget or -1 if nothing is focused.
+func (f *Form) Focus() int {
+	return f.focus
+}
+
+// Focus the widget with the given index.
+func (f *Form) SetFocus(i int) {
+	if i >= 0 && i < len(f.widgets) && f.widgets[i].Focusable() {
+		f.focus = i
+	}
+}
+
+// Show a modal dialog on top of the form.
+//
+// While the modal is open, it receives all input.
+func (f *Form) Open(m *Modal) {
+	f.modal = m
+	m.open = true
+}
+
+// Check if a modal dialog is open.
+func (f *Form) HasModal() bool {
+	return f.modal != nil && f.modal.open
+}
+
+func (f *Form) focusFirst() {
+	f.focus = -1
+	for i, w := range f.widgets {
+		if w.Focusable() {
+			f.focus = i
+			return
+		}
+	}
+}
+
+// Process the input.
+//
+// The pad and the buttons are usually from [firefly.ReadPad] and [firefly.ReadButtons].
+// If the pad isn't touched, pass false as touched.
+func (f *Form) Update(pad firefly.Pad, touched bool, buttons firefly.Buttons) {
+	pressed := buttons.JustPressed(f.prevBtn)
+	f.prevBtn = buttons
+	var dpad firefly.DPad8
+	if touched && !f.Pointer {
+		dpad = pad.DPad8()
+	}
+	newDir := dpad.JustPressed(f.prevPad)
+	f.prevPad = dpad
+
+	var events []Event
+	if f.Pointer && touched {
+		f.cursor = PadToScreen(pad)
+		events = append(events, Event{Kind: Touch, Point: f.cursor, Start: !f.touched})
+	}
+	f.touched = touched && f.Pointer
+	if newDir.Left {
+		events = append(events, Event{Kind: Left})
+	}
+	if newDir.Right {
+		events = append(events, Event{Kind: Right})
+	}
+	if newDir.Up {
+		events = append(events, Event{Kind: Up})
+	}
+	if newDir.Down {
+		events = append(events, Event{Kind: Down})
+	}
+	if pressed.S {
+		events = append(events, Event{Kind: Activate})
+	}
+	if pressed.E {
+		events = append(events, Event{Kind: Back})
+	}
+	for _, e := range events {
+		f.Send(e)
+	}
+}
+
+// Send the event to the modal or to the focused widget.
+//
+// If the widget doesn't handle a direction event, the focus moves in that direction.
+// A [Touch] event is sent only to the widget under the pointer.
+func (f *Form) Send(e Event) {
+	if f.HasModal() {
+		f.modal.Handle(e)
+		return
+	}
+	if e.Kind == Touch {
+		f.focusAt(e.Point)
+		if f.focus < 0 || !f.widgets[f.focus].Bounds().Contains(e.Point) {
+			return
+		}
+	}
+	if f.focus >= 0 && f.widgets[f.focus].Handle(e) {
+		return
+	}
+	switch e.Kind {
+	case Left, Right, Up, Down:
+		f.moveFocus(e.Kind)
+	case Activate, Back, Touch:
+	}
+}
+
+// Focus the widget under the pointer.
+func (f *Form) focusAt(p firefly.Point) {
+	for i, w := range f.widgets {
+		if w.Focusable() && w.Bounds().Contains(p) {
+			f.focus = i
+			return
+		}
+	}
+}
+
+// Move the focus to the closest widget in the given direction.
+func (f *Form) moveFocus(dir EventKind) {
+	if f.focus < 0 {
+		f.focusFirst()
+		return
+	}
+	from := f.widgets[f.focus].Bounds().Center()
+	best := -1
+	bestDist := 0
+	for i, w := range f.widgets {
+		if i == f.focus || !w.Focusable() {
+			continue
+		}
+		to := w.Bounds().Center()
+		dx, dy := to.X-from.X, to.Y-from.Y
+		// The main axis distance must be in the right direction
+		// and bigger than the distance on the other axis.
+		var main, cross int
+		switch dir {
+		case Left:
+			main, cross = -dx, dy
+		case Right:
+			main, cross = dx, dy
+		case Up:
+			main, cross = -dy, dx
+		case Down:
+			main, cross = dy, dx
+		case Activate, Back, Touch:
+			return
+		}
+		if main <= 0 || abs(cross) > main*2 {
+			continue
+		}
+		// Widgets on the same line are preferred.
+		dist := main + abs(cross)*2
+		if best < 0 || dist < bestDist {
+			best = i
+			bestDist = dist
+		}
+	}
+	if best >= 0 {
+		f.focus = best
+	}
+}
+
+// Render all widgets, the modal, and the pointer.
+func (f *Form) Draw() {
+	for i, w := range f.widgets {
+		w.Draw(f.Theme, i == f.focus && !f.HasModal())
+	}
+	if f.HasModal() {
+		f.modal.Draw(f.Theme, true)
+	}
+	if f.touched {
+		drawPointer(f.cursor, f.Theme.Accent)
+	}
+}
+
+// Convert the touch pad position into a point on the screen.
+func PadToScreen(pad firefly.Pad) firefly.Point {
+	x := (pad.X - firefly.PadMinX) * (firefly.Width - 1) / (firefly.PadMaxX - firefly.PadMinX)
+	// On the pad, Y points up. On the screen, it points down.
+	y := (firefly.PadMaxY - pad.Y) * (firefly.Height - 1) / (firefly.PadMaxY - firefly.PadMinY)
+	return firefly.P(x, y)
+}
+
+func drawPointer(p firefly.Point, c firefly.Color) {
+	style := firefly.L(c, 1)
+	firefly.DrawLine(p.Add(firefly.P(-3, 0)), p.Add(firefly.P(3, 0)), style)
+	firefly.DrawLine(p.Add(firefly.P(0, -3)), p.Add(firefly.P(0, 3)), style)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,225 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/ui"
+)
+
+func TestForm_Navigation(t *testing.T) {
+	t.Parallel()
+	f := ui.NewForm(ui.DefaultTheme(testFont))
+	f.Add(
+		&ui.Label{Rect: ui.R(10, 0, 60, 10), Text: "title"},
+		&ui.Button{Rect: ui.R(10, 20, 60, 14)},
+		&ui.Button{Rect: ui.R(80, 20, 60, 14)},
+		&ui.Button{Rect: ui.R(10, 40, 60, 14)},
+	)
+	if f.Focus() != 1 {
+		t.Fatalf("the first focusable widget must be focused, got %d", f.Focus())
+	}
+	steps := []struct {
+		kind ui.EventKind
+		want int
+	}{
+		{ui.Right, 2},
+		{ui.Right, 2},
+		{ui.Left, 1},
+		{ui.Down, 3},
+		{ui.Up, 1},
+		{ui.Up, 1},
+	}
+	for _, s := range steps {
+		f.Send(ui.Event{Kind: s.kind})
+		if f.Focus() != s.want {
+			t.Fatalf("after %d: want focus %d, got %d", s.kind, s.want, f.Focus())
+		}
+	}
+}
+
+func TestForm_Update(t *testing.T) {
+	t.Parallel()
+	clicks := 0
+	f := ui.NewForm(ui.DefaultTheme(testFont))
+	f.Add(
+		&ui.Button{Rect: ui.R(0, 0, 60, 14), OnClick: func() { clicks++ }},
+		&ui.Button{Rect: ui.R(0, 20, 60, 14)},
+	)
+	press := firefly.Buttons{S: true}
+	f.Update(firefly.Pad{}, false, press)
+	f.Update(firefly.Pad{}, false, press)
+	if clicks != 1 {
+		t.Fatalf("holding the button must click once, got %d clicks", clicks)
+	}
+	down := firefly.Pad{Y: -500}
+	f.Update(down, true, firefly.Buttons{})
+	f.Update(down, true, firefly.Buttons{})
+	if f.Focus() != 1 {
+		t.Fatalf("holding the pad must move the focus once, got %d", f.Focus())
+	}
+
+	f.Pointer = true
+	corner := firefly.Pad{X: firefly.PadMinX, Y: firefly.PadMaxY}
+	f.Update(corner, true, firefly.Buttons{})
+	if f.Focus() != 0 {
+		t.Fatalf("touching a widget must focus it, got %d", f.Focus())
+	}
+	f.Update(corner, true, firefly.Buttons{})
+	if clicks != 2 {
+		t.Fatalf("holding the touch must click once, got %d clicks", clicks)
+	}
+}
+
+func TestForm_Diagonal(t *testing.T) {
+	t.Parallel()
+	f := ui.NewForm(ui.DefaultTheme(testFont))
+	f.Add(
+		&ui.Button{Rect: ui.R(10, 20, 60, 14)},
+		&ui.Button{Rect: ui.R(80, 20, 60, 14)},
+		&ui.Button{Rect: ui.R(80, 40, 60, 14)},
+	)
+	f.Update(firefly.Pad{X: 700, Y: -700}, true, firefly.Buttons{})
+	if f.Focus() != 2 {
+		t.Fatalf("a diagonal must move the focus along both axes, got %d", f.Focus())
+	}
+}
+
+func TestSlider(t *testing.T) {
+	t.Parallel()
+	var changes []int
+	s := &ui.Slider{
+		Rect: ui.R(0, 0, 101, 10), Min: 0, Max: 10, Step: 4, Value: 6,
+		OnChange: func(v int) { changes = append(changes, v) },
+	}
+	s.Handle(ui.Event{Kind: ui.Right})
+	s.Handle(ui.Event{Kind: ui.Right})
+	if s.Value != 10 {
+		t.Errorf("the value must be clamped to max, got %d", s.Value)
+	}
+	s.Handle(ui.Event{Kind: ui.Touch, Point: firefly.P(30, 5)})
+	if s.Value != 4 {
+		t.Errorf("touch must snap to the step, got %d", s.Value)
+	}
+	if len(changes) != 2 {
+		t.Errorf("want 2 changes, got %v", changes)
+	}
+	if s.Handle(ui.Event{Kind: ui.Up}) {
+		t.Errorf("the slider must not handle vertical movement")
+	}
+	if s.Handle(ui.Event{Kind: ui.Touch, Point: firefly.P(90, 20)}) || s.Value != 4 {
+		t.Errorf("a touch outside of the slider must be ignored, got %d", s.Value)
+	}
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+	l := &ui.List{Items: []string{"a", "b", "c", "d", "e"}}
+	if l.Handle(ui.Event{Kind: ui.Up}) {
+		t.Errorf("moving up from the first item must not be handled")
+	}
+	for range 4 {
+		l.Handle(ui.Event{Kind: ui.Down})
+	}
+	if l.Selected != 4 {
+		t.Errorf("want item 4 selected, got %d", l.Selected)
+	}
+	if l.Handle(ui.Event{Kind: ui.Down}) {
+		t.Errorf("moving down from the last item must not be handled")
+	}
+	l.ScrollTo(2)
+	if l.Scroll() != 3 {
+		t.Errorf("want scroll 3, got %d", l.Scroll())
+	}
+	l.Selected = 1
+	l.ScrollTo(2)
+	if l.Scroll() != 1 {
+		t.Errorf("want scroll 1, got %d", l.Scroll())
+	}
+	if l.Handle(ui.Event{Kind: ui.Touch, Point: firefly.P(5, 5)}) || l.Selected != 1 {
+		t.Errorf("a touch outside of the list must be ignored, got %d", l.Selected)
+	}
+}
+
+func TestTabs(t *testing.T) {
+	t.Parallel()
+	var changes []int
+	tabs := &ui.Tabs{
+		Rect: ui.R(20, 0, 90, 10), Tabs: []string{"a", "b", "c"},
+		OnChange: func(i int) { changes = append(changes, i) },
+	}
+	tabs.Handle(ui.Event{Kind: ui.Touch, Point: firefly.P(80, 5)})
+	if tabs.Active != 2 {
+		t.Errorf("touching a tab must activate it, got %d", tabs.Active)
+	}
+	if tabs.Handle(ui.Event{Kind: ui.Touch, Point: firefly.P(5, 5)}) || tabs.Active != 2 {
+		t.Errorf("a touch left of the tabs must be ignored, got %d", tabs.Active)
+	}
+	if len(changes) != 1 {
+		t.Errorf("want 1 change, got %v", changes)
+	}
+}
+
+func TestForm_TouchOutside(t *testing.T) {
+	t.Parallel()
+	f := ui.NewForm(ui.DefaultTheme(testFont))
+	s := &ui.Slider{Rect: ui.R(0, 0, 101, 10), Max: 10, Value: 5}
+	f.Add(s)
+	f.Send(ui.Event{Kind: ui.Touch, Point: firefly.P(100, 100), Start: true})
+	if s.Value != 5 {
+		t.Errorf("a touch outside of all widgets must be dropped, got %d", s.Value)
+	}
+}
+
+func TestModal(t *testing.T) {
+	t.Parallel()
+	f := ui.NewForm(ui.DefaultTheme(testFont))
+	clicked := false
+	f.Add(&ui.Button{Rect: ui.R(0, 0, 60, 14), OnClick: func() { clicked = true }})
+	result := 0
+	m := &ui.Modal{Buttons: []string{"yes", "no"}, OnClose: func(i int) { result = i }}
+	f.Open(m)
+	f.Send(ui.Event{Kind: ui.Right})
+	f.Send(ui.Event{Kind: ui.Activate})
+	if clicked {
+		t.Errorf("the modal must capture all input")
+	}
+	if f.HasModal() || result != 1 {
+		t.Errorf("the modal must be closed with 1, got open=%v result=%d", f.HasModal(), result)
+	}
+	f.Open(m)
+	f.Send(ui.Event{Kind: ui.Back})
+	if result != -1 {
+		t.Errorf("canceling must close with -1, got %d", result)
+	}
+	// The modal is centered, the first button is in the bottom-left corner.
+	f.Open(m)
+	f.Send(ui.Event{Kind: ui.Touch, Point: firefly.P(50, 105), Start: true})
+	if f.HasModal() || result != 0 {
+		t.Errorf("touching a button must close with it, got open=%v result=%d", f.HasModal(), result)
+	}
+
+	empty := &ui.Modal{OnClose: func(i int) { result = i }}
+	f.Open(empty)
+	f.Send(ui.Event{Kind: ui.Activate})
+	if result != -1 {
+		t.Errorf("a modal without buttons must close with -1, got %d", result)
+	}
+}
+
+func TestPadToScreen(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		pad  firefly.Pad
+		want firefly.Point
+	}{
+		{firefly.Pad{X: firefly.PadMinX, Y: firefly.PadMaxY}, firefly.P(0, 0)},
+		{firefly.Pad{X: firefly.PadMaxX, Y: firefly.PadMinY}, firefly.P(firefly.Width-1, firefly.Height-1)},
+	}
+	for _, c := range cases {
+		got := ui.PadToScreen(c.pad)
+		if got != c.want {
+			t.Errorf("PadToScreen(%v): want %v, got %v", c.pad, c.want, got)
+		}
+	}
+}
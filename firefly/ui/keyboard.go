@@ -0,0 +1,298 @@
+package ui
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+const (
+	// How many directions (petals) the radial mode has.
+	petals = 8
+
+	// How many keys each petal has, one for each of the S, E, W, N buttons.
+	petalSize = 4
+
+	// The minimum distance from the pad center for a petal to be selected.
+	petalThreshold = 400
+)
+
+// One-character labels for special keys in the radial mode.
+var shortLabels = [...]string{
+	KeyShift:     "^",
+	KeySpace:     "_",
+	KeyBackspace: "<",
+	KeyEnter:     ">",
+}
+
+// An on-screen keyboard for typing text.
+//
+// In the grid mode, the player moves the cursor over the keys with the pad
+// as a D-pad, presses [firefly.Buttons].S to type the selected key,
+// and [firefly.Buttons].E to remove the last character.
+// [firefly.Buttons].W switches the shift and [firefly.Buttons].N types a space.
+//
+// In the radial mode, the keys are split into 8 petals around the pad center,
+// 4 keys in each. Touching the pad in a direction selects a petal,
+// and then one of the 4 buttons types the key in the matching position of the petal.
+// Without a petal selected, the buttons work as in the grid mode,
+// except [firefly.Buttons].S which switches to the next page of petals.
+//
+// Constructed by [NewKeyboard].
+type Keyboard struct {
+	Theme  Theme
+	Layout Layout
+
+	// The top-left corner of the keyboard. The text field is drawn above it.
+	Point firefly.Point
+
+	// The maximum number of characters in the text. Zero means no limit.
+	MaxLen int
+
+	// If true, letters are typed in uppercase.
+	Shift bool
+
+	// If true, the radial mode is used instead of the grid mode.
+	Radial bool
+
+	// Called when the enter key is pressed.
+	OnSubmit func(text string)
+
+	text    []rune
+	row     int
+	col     int
+	page    int
+	petal   int
+	prevPad firefly.DPad4
+	prevBtn firefly.Buttons
+}
+
+// Create a keyboard for the given language at the bottom of the screen.
+func NewKeyboard(theme Theme, lang firefly.Language) *Keyboard {
+	layout := LayoutFor(lang)
+	k := &Keyboard{Theme: theme, Layout: layout, petal: -1}
+	k.Point = firefly.P(0, firefly.Height-len(layout.Rows)*k.keyHeight())
+	return k
+}
+
+// The typed text.
+func (k *Keyboard) Text() string {
+	return string(k.text)
+}
+
+// Replace the typed text.
+func (k *Keyboard) SetText(s string) {
+	k.text = []rune(s)
+}
+
+// The key under the cursor in the grid mode.
+func (k *Keyboard) Selected() Key {
+	return k.Layout.Rows[k.row][k.col]
+}
+
+// The selected petal in the radial mode or -1 if none is selected.
+//
+// Petals are counted counter-clockwise starting from the right one.
+func (k *Keyboard) Petal() int {
+	return k.petal
+}
+
+// The keys of the petal on the current page.
+//
+// The keys are in the order of buttons: N, W, E, S.
+func (k *Keyboard) PetalKeys(petal int) []Key {
+	keys := k.Layout.Keys()
+	start := (k.page*petals + petal) * petalSize
+	if start >= len(keys) {
+		return nil
+	}
+	return keys[start:min(start+petalSize, len(keys))]
+}
+
+// Apply the key as if it was pressed by the player.
+func (k *Keyboard) Press(key Key) {
+	switch key.Kind {
+	case KeyRune:
+		r := key.Rune
+		if k.Shift {
+			r = k.Layout.Upper(r)
+		}
+		k.typeRune(r)
+	case KeySpace:
+		k.typeRune(' ')
+	case KeyBackspace:
+		if len(k.text) > 0 {
+			k.text = k.text[:len(k.text)-1]
+		}
+	case KeyShift:
+		k.Shift = !k.Shift
+	case KeyEnter:
+		if k.OnSubmit != nil {
+			k.OnSubmit(k.Text())
+		}
+	}
+}
+
+func (k *Keyboard) typeRune(r rune) {
+	if k.MaxLen > 0 && len(k.text) >= k.MaxLen {
+		return
+	}
+	k.text = append(k.text, r)
+}
+
+// Process the input.
+//
+// The pad and the buttons are usually from [firefly.ReadPad] and [firefly.ReadButtons].
+// If the pad isn't touched, pass false as touched.
+func (k *Keyboard) Update(pad firefly.Pad, touched bool, buttons firefly.Buttons) {
+	pressed := buttons.JustPressed(k.prevBtn)
+	k.prevBtn = buttons
+	if k.Radial {
+		k.updateRadial(pad, touched, pressed)
+		return
+	}
+	dpad := firefly.DPad4None
+	if touched {
+		dpad = pad.DPad4()
+	}
+	k.move(dpad.JustPressed(k.prevPad))
+	k.prevPad = dpad
+	switch {
+	case pressed.S:
+		k.Press(k.Selected())
+	case pressed.E:
+		k.Press(Key{Kind: KeyBackspace})
+	case pressed.W:
+		k.Press(Key{Kind: KeyShift})
+	case pressed.N:
+		k.Press(Key{Kind: KeySpace})
+	}
+}
+
+// Move the cursor in the grid mode.
+//
+// The cursor wraps around the edges. When moving between rows of different length,
+// the cursor keeps its relative horizontal position.
+func (k *Keyboard) move(dir firefly.DPad4) {
+	rows := k.Layout.Rows
+	switch dir {
+	case firefly.DPad4Left:
+		k.col = (k.col + len(rows[k.row]) - 1) % len(rows[k.row])
+	case firefly.DPad4Right:
+		k.col = (k.col + 1) % len(rows[k.row])
+	case firefly.DPad4Up:
+		k.setRow((k.row + len(rows) - 1) % len(rows))
+	case firefly.DPad4Down:
+		k.setRow((k.row + 1) % len(rows))
+	case firefly.DPad4None:
+	}
+}
+
+func (k *Keyboard) setRow(row int) {
+	oldLen := len(k.Layout.Rows[k.row])
+	newLen := len(k.Layout.Rows[row])
+	k.col = (k.col*2 + 1) * newLen / (oldLen * 2)
+	k.row = row
+}
+
+func (k *Keyboard) updateRadial(pad firefly.Pad, touched bool, pressed firefly.Buttons) {
+	k.petal = -1
+	if touched && pad.RadiusSquared() >= petalThreshold*petalThreshold {
+		sector := int(tinymath.Round(pad.Azimuth().Degrees() / (360 / petals)))
+		k.petal = (sector%petals + petals) % petals
+	}
+	if k.petal < 0 {
+		switch {
+		case pressed.S:
+			k.nextPage()
+		case pressed.E:
+			k.Press(Key{Kind: KeyBackspace})
+		case pressed.W:
+			k.Press(Key{Kind: KeyShift})
+		case pressed.N:
+			k.Press(Key{Kind: KeySpace})
+		}
+		return
+	}
+	keys := k.PetalKeys(k.petal)
+	for i, p := range [petalSize]bool{pressed.N, pressed.W, pressed.E, pressed.S} {
+		if p && i < len(keys) {
+			k.Press(keys[i])
+		}
+	}
+}
+
+func (k *Keyboard) nextPage() {
+	pages := (len(k.Layout.Keys()) + petals*petalSize - 1) / (petals * petalSize)
+	k.page = (k.page + 1) % max(pages, 1)
+}
+
+func (k *Keyboard) keyHeight() int {
+	return k.Theme.Font.CharHeight() + 6
+}
+
+// Render the keyboard and the text field above it.
+func (k *Keyboard) Draw() {
+	t := k.Theme
+	keyH := k.keyHeight()
+	field := R(k.Point.X, k.Point.Y-keyH, firefly.Width-k.Point.X, keyH)
+	field.Draw(firefly.Style{FillColor: t.BG, StrokeColor: t.Primary, StrokeWidth: 1})
+	field.X += 3
+	t.drawText(k.Text()+"_", field, t.Primary, false)
+	if k.Radial {
+		k.drawRadial()
+		return
+	}
+	width := firefly.Width - k.Point.X
+	for r, row := range k.Layout.Rows {
+		keyW := width / len(row)
+		for c, key := range row {
+			rect := R(k.Point.X+c*keyW, k.Point.Y+r*keyH, keyW, keyH)
+			color := t.Primary
+			if r == k.row && c == k.col {
+				rect.Draw(firefly.Solid(t.Accent))
+				color = t.BG
+			} else {
+				rect.Draw(firefly.Style{FillColor: t.BG, StrokeColor: t.Secondary, StrokeWidth: 1})
+			}
+			t.drawText(k.Layout.Label(key, k.Shift), rect, color, true)
+		}
+	}
+}
+
+func (k *Keyboard) drawRadial() {
+	t := k.Theme
+	height := len(k.Layout.Rows) * k.keyHeight()
+	area := R(k.Point.X, k.Point.Y, firefly.Width-k.Point.X, height)
+	area.Draw(firefly.Solid(t.BG))
+	center := area.Center()
+	charW := t.Font.CharWidth()
+	charH := t.Font.CharHeight()
+	// Petals are placed on an ellipse to use all the available space.
+	rx := float32(min(area.W/2-charW*2, area.H))
+	ry := float32(area.H/2 - charH)
+	for i := range petals {
+		angle := firefly.Degrees(float32(i * 360 / petals))
+		sin, cos := tinymath.SinCos(angle.Radians())
+		// On the screen, Y points down.
+		pc := center.Add(firefly.P(int(cos*rx), -int(sin*ry)))
+		color := t.Secondary
+		if i == k.petal {
+			color = t.Accent
+		}
+		// Keys are placed like the buttons they are typed with: N, W, E, S.
+		offsets := [petalSize]firefly.Point{
+			firefly.P(0, -charH/2-1),
+			firefly.P(-charW-1, 0),
+			firefly.P(charW+1, 0),
+			firefly.P(0, charH/2+1),
+		}
+		for j, key := range k.PetalKeys(i) {
+			label := shortLabels[key.Kind]
+			if key.Kind == KeyRune {
+				label = k.Layout.Label(key, k.Shift)
+			}
+			p := pc.Add(offsets[j])
+			firefly.DrawText(label, t.Font, p.Add(firefly.P(-charW/2, charH/2)), color)
+		}
+	}
+}
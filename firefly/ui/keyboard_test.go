@@ -0,0 +1,123 @@
+package ui_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/ui"
+)
+
+func hasRune(l ui.Layout, r rune) bool {
+	return slices.Contains(l.Keys(), ui.Key{Kind: ui.KeyRune, Rune: r})
+}
+
+func TestLayoutFor(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		lang    firefly.Language
+		has     rune
+		hasNot  rune
+		upper   rune
+		upperOf rune
+	}{
+		{firefly.English, 'q', 'é', 'Q', 'q'},
+		{firefly.German, 'ß', 'é', 'Ä', 'ä'},
+		// ISO 8859-2 has "é" but not "è".
+		{firefly.French, 'é', 'è', 'Ç', 'ç'},
+		{firefly.Spanish, 'ñ', 'ą', 'Ñ', 'ñ'},
+		// ISO 8859-5 doesn't have "ґ".
+		{firefly.Ukrainian, 'ї', 'ґ', 'Ї', 'ї'},
+		{firefly.Turkish, 'ı', 'ß', 'İ', 'i'},
+		{firefly.TokiPona, 'j', 'q', 'J', 'j'},
+	}
+	for _, c := range cases {
+		l := ui.LayoutFor(c.lang)
+		if !hasRune(l, c.has) {
+			t.Errorf("%s: want %q in the layout", c.lang.Code(), c.has)
+		}
+		if hasRune(l, c.hasNot) {
+			t.Errorf("%s: want no %q in the layout", c.lang.Code(), c.hasNot)
+		}
+		if got := l.Upper(c.upperOf); got != c.upper {
+			t.Errorf("%s: want %q as uppercase of %q, got %q", c.lang.Code(), c.upper, c.upperOf, got)
+		}
+	}
+	// Uppercase "ß" isn't in any of the supported encodings.
+	if got := ui.LayoutFor(firefly.German).Upper('ß'); got != 'ß' {
+		t.Errorf("want ß to stay lowercase, got %q", got)
+	}
+}
+
+func TestKeyboard_Grid(t *testing.T) {
+	t.Parallel()
+	k := ui.NewKeyboard(ui.DefaultTheme(testFont), firefly.English)
+	submitted := ""
+	k.OnSubmit = func(s string) { submitted = s }
+	press := func(b firefly.Buttons) {
+		k.Update(firefly.Pad{}, false, b)
+		k.Update(firefly.Pad{}, false, firefly.Buttons{})
+	}
+	move := func(p firefly.Pad) {
+		k.Update(p, true, firefly.Buttons{})
+		k.Update(p, false, firefly.Buttons{})
+	}
+	down := firefly.Pad{Y: -800}
+	left := firefly.Pad{X: -800}
+
+	// From "1", go down to "q" and then wrap around to "p".
+	move(down)
+	press(firefly.Buttons{S: true})
+	move(left)
+	press(firefly.Buttons{W: true})
+	press(firefly.Buttons{S: true})
+	press(firefly.Buttons{N: true})
+	press(firefly.Buttons{S: true})
+	press(firefly.Buttons{E: true})
+	if k.Text() != "qP " {
+		t.Fatalf("want %q, got %q", "qP ", k.Text())
+	}
+
+	// Go to the enter key in the last row.
+	for k.Selected().Kind == ui.KeyRune {
+		move(down)
+	}
+	for k.Selected().Kind != ui.KeyEnter {
+		move(left)
+	}
+	press(firefly.Buttons{S: true})
+	if submitted != "qP " {
+		t.Fatalf("want %q submitted, got %q", "qP ", submitted)
+	}
+}
+
+func TestKeyboard_Radial(t *testing.T) {
+	t.Parallel()
+	k := ui.NewKeyboard(ui.DefaultTheme(testFont), firefly.English)
+	k.Radial = true
+	k.MaxLen = 2
+	right := firefly.Pad{X: 900}
+	up := firefly.Pad{Y: 900}
+
+	k.Update(right, true, firefly.Buttons{N: true})
+	if k.Petal() != 0 {
+		t.Fatalf("want petal 0, got %d", k.Petal())
+	}
+	k.Update(up, true, firefly.Buttons{})
+	if k.Petal() != 2 {
+		t.Fatalf("want petal 2, got %d", k.Petal())
+	}
+	k.Update(up, true, firefly.Buttons{S: true})
+	k.Update(up, true, firefly.Buttons{})
+	// The text is already at the max length.
+	k.Update(up, true, firefly.Buttons{E: true})
+	if k.Text() != "1w" {
+		t.Fatalf("want %q, got %q", "1w", k.Text())
+	}
+	// Without a petal, E is backspace.
+	k.Update(firefly.Pad{}, false, firefly.Buttons{})
+	k.Update(firefly.Pad{}, false, firefly.Buttons{E: true})
+	if k.Text() != "1" {
+		t.Fatalf("want %q, got %q", "1", k.Text())
+	}
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// The kind of a [Key] on the [Keyboard].
+type KeyKind uint8
+
+const (
+	// A key that types a character.
+	KeyRune KeyKind = iota
+	// Switch between lowercase and uppercase letters.
+	KeyShift
+	// Type a space.
+	KeySpace
+	// Remove the last character.
+	KeyBackspace
+	// Finish typing.
+	KeyEnter
+)
+
+// A single key of a [Layout].
+type Key struct {
+	Kind KeyKind
+
+	// The character typed by the key. Used only for [KeyRune].
+	Rune rune
+}
+
+// Keys of an on-screen [Keyboard].
+//
+// Constructed by [LayoutFor].
+type Layout struct {
+	// The language the layout is for.
+	//
+	// Used to correctly convert letters to uppercase.
+	Language firefly.Language
+
+	// Rows of keys, from top to bottom.
+	//
+	// The last row contains shift, space, backspace, and enter keys.
+	Rows [][]Key
+}
+
+// Rows of characters for each language.
+//
+// The characters that can't be represented in the language encoding
+// are removed by [LayoutFor], so it's fine to list here all letters
+// that the language uses.
+var layouts = map[firefly.Language][]string{
+	firefly.English:   {"1234567890", "qwertyuiop", "asdfghjkl'", "zxcvbnm,.?!"},
+	firefly.Dutch:     {"1234567890", "qwertyuiop", "asdfghjkl'", "zxcvbnm,.?!"},
+	firefly.French:    {"1234567890", "azertyuiop", "qsdfghjklm", "wxcvbn',.?!", "éèêàâùûçîô"},
+	firefly.German:    {"1234567890", "qwertzuiopü", "asdfghjklöä", "yxcvbnmß,.?!"},
+	firefly.Italian:   {"1234567890", "qwertyuiop", "asdfghjkl'", "zxcvbnm,.?!", "àèéìòù"},
+	firefly.Polish:    {"1234567890", "qwertyuiop", "asdfghjkl'", "zxcvbnm,.?!", "ąćęłńóśźż"},
+	firefly.Romanian:  {"1234567890", "qwertyuiop", "asdfghjkl'", "zxcvbnm,.?!", "ăâîșț"},
+	firefly.Russian:   {"1234567890", "йцукенгшщзхъ", "фывапролджэ", "ячсмитьбюё", ",.?!-"},
+	firefly.Spanish:   {"1234567890", "qwertyuiop", "asdfghjklñ", "zxcvbnm,.?!", "áéíóúü¿¡"},
+	firefly.Swedish:   {"1234567890", "qwertyuiopå", "asdfghjklöä", "zxcvbnm,.?!"},
+	firefly.Turkish:   {"1234567890", "qwertyuıopğü", "asdfghjklşi", "zxcvbnmöç,.?!"},
+	firefly.Ukrainian: {"1234567890", "йцукенгшщзхї", "фівапролджє", "ячсмитьбюґ", ",.?!-'"},
+	// Toki Pona needs only 14 letters.
+	firefly.TokiPona: {"aeiou", "jklmn", "pstw", ",.?!"},
+}
+
+// Non-ASCII characters that can be represented in each of the encodings.
+//
+// Only letters and punctuation that can be used on the keyboard are listed.
+// Letters are listed in lowercase. Uppercase letters are supported
+// unless they are listed in lowerOnly.
+var charsets = map[string]struct{ chars, lowerOnly string }{
+	"iso_8859_1":  {"¡¿àáâãäåæçèéêëìíîïðñòóôõöøùúûüýþÿß", "ÿß"},
+	"iso_8859_2":  {"ąłľśšşťźžżŕáâăäĺćçčéęëěíîďđńňóôőöřůúűüýţß", "ß"},
+	"iso_8859_5":  {"абвгдежзийклмнопрстуфхцчшщъыьэюяёђѓєѕіїјљњћќўџ", ""},
+	"iso_8859_9":  {"¡¿àáâãäåæçèéêëìíîïğñòóôõöøùúûüışÿßİ", "ÿß"},
+	"iso_8859_13": {"ąįāćäåęēčéźėģķīļšńņóōõöøųłśūüżžæß", "ß"},
+	"iso_8859_16": {"ąłżšžźàáâăäćæçèéêëìíîïđńòóôőöśűùúûüęțșÿß", "ß"},
+}
+
+// Check if the character can be represented in the given encoding.
+//
+// The encoding name is as returned by [firefly.Language.Encoding].
+func encodable(r rune, encoding string) bool {
+	if r >= ' ' && r <= '~' {
+		return true
+	}
+	set, ok := charsets[encoding]
+	if !ok {
+		return false
+	}
+	if strings.ContainsRune(set.chars, r) {
+		return true
+	}
+	lower := unicode.ToLower(r)
+	if lower == r || strings.ContainsRune(set.lowerOnly, lower) {
+		return false
+	}
+	return strings.ContainsRune(set.chars, lower)
+}
+
+// The keyboard layout for the given language.
+//
+// Only characters that can be represented in [firefly.Language.Encoding]
+// are included. Unknown languages get the English layout.
+func LayoutFor(lang firefly.Language) Layout {
+	rows, ok := layouts[lang]
+	if !ok {
+		rows = layouts[firefly.English]
+	}
+	encoding := lang.Encoding()
+	layout := Layout{Language: lang}
+	for _, row := range rows {
+		keys := make([]Key, 0, len(row))
+		for _, r := range row {
+			if encodable(r, encoding) {
+				keys = append(keys, Key{Kind: KeyRune, Rune: r})
+			}
+		}
+		if len(keys) > 0 {
+			layout.Rows = append(layout.Rows, keys)
+		}
+	}
+	layout.Rows = append(layout.Rows, []Key{
+		{Kind: KeyShift},
+		{Kind: KeySpace},
+		{Kind: KeyBackspace},
+		{Kind: KeyEnter},
+	})
+	return layout
+}
+
+// Convert the character to uppercase.
+//
+// If the uppercase letter can't be represented in the language encoding,
+// the character is returned as is.
+func (l Layout) Upper(r rune) rune {
+	var upper rune
+	if l.Language == firefly.Turkish {
+		upper = unicode.TurkishCase.ToUpper(r)
+	} else {
+		upper = unicode.ToUpper(r)
+	}
+	if !encodable(upper, l.Language.Encoding()) {
+		return r
+	}
+	return upper
+}
+
+// All keys of the layout, row by row.
+func (l Layout) Keys() []Key {
+	var keys []Key
+	for _, row := range l.Rows {
+		keys = append(keys, row...)
+	}
+	return keys
+}
+
+// The text shown on the key.
+func (l Layout) Label(k Key, shift bool) string {
+	switch k.Kind {
+	case KeyShift:
+		if shift {
+			return "abc"
+		}
+		return "ABC"
+	case KeySpace:
+		return "space"
+	case KeyBackspace:
+		return "del"
+	case KeyEnter:
+		return "ok"
+	case KeyRune:
+	}
+	if shift {
+		return string(l.Upper(k.Rune))
+	}
+	return string(k.Rune)
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/orsinium-labs/tinymath"
+)
+
+// An item of [RadialMenu].
+type RadialItem struct {
+	// The text shown in the segment if there is no icon.
+	//
+	// If there is an icon, the text is shown in the menu center when the item is selected.
+	Label string
+
+	// The icon shown in the segment, usually from [firefly.Atlas.Sprite].
+	Icon firefly.Sprite
+
+	// If true, the item is shown but can't be selected.
+	Disabled bool
+}
+
+// A pie menu: items are placed in a circle, and the touch pad direction selects one.
+//
+// Items are placed clockwise starting from the top one.
+// The selected item is confirmed by releasing the pad (if [RadialMenu.ConfirmOnRelease] is set)
+// or by pressing [firefly.Buttons].S. The [firefly.Buttons].E cancels the menu.
+//
+// Constructed by [NewRadialMenu].
+type RadialMenu struct {
+	// The colors of the menu. The selected item is highlighted with [Theme].Accent.
+	//
+	// Use [SystemTheme] to match the player's settings.
+	Theme Theme
+
+	// The center of the menu on the screen.
+	Center firefly.Point
+
+	// The diameter of the menu circle.
+	Diameter int
+
+	Items []RadialItem
+
+	// The minimum distance from the pad center for an item to be selected.
+	DeadZone int
+
+	// If true, releasing the pad confirms the selected item.
+	ConfirmOnRelease bool
+
+	// Called with the index of the confirmed item.
+	OnSelect func(i int)
+
+	// Called when the menu is cancelled.
+	OnCancel func()
+
+	selected int
+	prevBtn  firefly.Buttons
+	// The item was confirmed by a button during the current touch,
+	// so releasing the pad must not confirm it again.
+	confirmed bool
+}
+
+// Create a radial menu in the center of the screen.
+func NewRadialMenu(theme Theme, items ...RadialItem) *RadialMenu {
+	return &RadialMenu{
+		Theme:            theme,
+		Center:           firefly.P(firefly.Width/2, firefly.Height/2),
+		Diameter:         firefly.Height - 8,
+		Items:            items,
+		DeadZone:         400,
+		ConfirmOnRelease: true,
+		selected:         -1,
+	}
+}
+
+// The index of the selected item or -1 if nothing is selected.
+func (m *RadialMenu) Selected() int {
+	return m.selected
+}
+
+// The index of the item in the pad direction or -1.
+//
+// Disabled items and the dead zone in the pad center don't select anything.
+func (m *RadialMenu) ItemAt(pad firefly.Pad) int {
+	n := len(m.Items)
+	if n == 0 || pad.RadiusSquared() < m.DeadZone*m.DeadZone {
+		return -1
+	}
+	// The azimuth goes counter-clockwise from the right
+	// but the items go clockwise from the top.
+	angle := 90 - pad.Azimuth().Degrees()
+	i := int(tinymath.Round(angle*float32(n)/360)) % n
+	if i < 0 {
+		i += n
+	}
+	if m.Items[i].Disabled {
+		return -1
+	}
+	return i
+}
+
+// Process the input.
+//
+// The pad and the buttons are usually from [firefly.ReadPad] and [firefly.ReadButtons].
+// If the pad isn't touched, pass false as touched.
+func (m *RadialMenu) Update(pad firefly.Pad, touched bool, buttons firefly.Buttons) {
+	pressed := buttons.JustPressed(m.prevBtn)
+	m.prevBtn = buttons
+	if pressed.E {
+		m.selected = -1
+		if m.OnCancel != nil {
+			m.OnCancel()
+		}
+		return
+	}
+	if !touched {
+		// The item selected on the previous frame is confirmed on release.
+		if m.ConfirmOnRelease && m.selected >= 0 && !m.confirmed {
+			m.confirm(m.selected)
+		}
+		m.selected = -1
+		m.confirmed = false
+		return
+	}
+	m.selected = m.ItemAt(pad)
+	if pressed.S && m.selected >= 0 {
+		m.confirm(m.selected)
+		m.confirmed = true
+	}
+}
+
+func (m *RadialMenu) confirm(i int) {
+	if m.OnSelect != nil {
+		m.OnSelect(i)
+	}
+}
+
+// Render the menu.
+func (m *RadialMenu) Draw() {
+	t := m.Theme
+	n := len(m.Items)
+	if n == 0 {
+		return
+	}
+	d := m.Diameter
+	corner := m.Center.Sub(firefly.P(d/2, d/2))
+	sweep := float32(360) / float32(n)
+	for i, item := range m.Items {
+		// On the screen, angles go clockwise from the right, so the top is -90°.
+		mid := -90 + sweep*float32(i)
+		style := firefly.Style{FillColor: t.BG, StrokeColor: t.Primary, StrokeWidth: 1}
+		color := t.Primary
+		if i == m.selected {
+			style.FillColor = t.Accent
+			color = t.BG
+		} else if item.Disabled {
+			color = t.Secondary
+		}
+		start := firefly.Degrees(mid - sweep/2)
+		firefly.DrawSector(corner, d, start, firefly.Degrees(sweep), style)
+
+		// Icons are placed in the middle between the center circle and the edge.
+		sin, cos := tinymath.SinCos(firefly.Degrees(mid).Radians())
+		r := float32(d) * 3 / 8
+		p := m.Center.Add(firefly.P(int(cos*r), int(sin*r)))
+		if item.Icon.Exists() {
+			size := item.Icon.Size()
+			item.Icon.Draw(p.Sub(firefly.P(size.W/2, size.H/2)))
+			continue
+		}
+		charH := t.Font.CharHeight()
+		t.drawText(item.Label, R(p.X-d/2, p.Y-charH, d, charH*2), color, true)
+	}
+
+	inner := d / 4
+	firefly.DrawCircle(
+		m.Center.Sub(firefly.P(inner/2, inner/2)), inner,
+		firefly.Style{FillColor: t.BG, StrokeColor: t.Primary, StrokeWidth: 1},
+	)
+	if m.selected >= 0 && m.Items[m.selected].Icon.Exists() {
+		label := m.Items[m.selected].Label
+		charH := t.Font.CharHeight()
+		t.drawText(label, R(m.Center.X-d/2, m.Center.Y-charH, d, charH*2), t.Primary, true)
+	}
+}
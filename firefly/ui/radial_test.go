@@ -0,0 +1,84 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+	"github.com/firefly-zero/firefly-go/firefly/ui"
+)
+
+func newRadialMenu() *ui.RadialMenu {
+	return ui.NewRadialMenu(
+		ui.DefaultTheme(testFont),
+		ui.RadialItem{Label: "up"},
+		ui.RadialItem{Label: "right"},
+		ui.RadialItem{Label: "down", Disabled: true},
+		ui.RadialItem{Label: "left"},
+	)
+}
+
+func TestRadialMenu_ItemAt(t *testing.T) {
+	t.Parallel()
+	m := newRadialMenu()
+	tests := []struct {
+		pad  firefly.Pad
+		want int
+	}{
+		{firefly.Pad{Y: 900}, 0},
+		{firefly.Pad{X: 300, Y: 900}, 0},
+		{firefly.Pad{X: 900, Y: 300}, 1},
+		{firefly.Pad{X: 900, Y: -300}, 1},
+		{firefly.Pad{Y: -900}, -1},
+		{firefly.Pad{X: -900, Y: 100}, 3},
+		{firefly.Pad{X: -600, Y: 700}, 0},
+		{firefly.Pad{X: 100, Y: 100}, -1},
+	}
+	for _, tc := range tests {
+		if got := m.ItemAt(tc.pad); got != tc.want {
+			t.Errorf("%v: want %d, got %d", tc.pad, tc.want, got)
+		}
+	}
+}
+
+func TestRadialMenu_Update(t *testing.T) {
+	t.Parallel()
+	m := newRadialMenu()
+	confirmed := -1
+	cancelled := false
+	m.OnSelect = func(i int) { confirmed = i }
+	m.OnCancel = func() { cancelled = true }
+
+	m.Update(firefly.Pad{X: 900}, true, firefly.Buttons{})
+	if m.Selected() != 1 || confirmed != -1 {
+		t.Fatalf("the item must be selected but not confirmed")
+	}
+	m.Update(firefly.Pad{}, false, firefly.Buttons{})
+	if confirmed != 1 || m.Selected() != -1 {
+		t.Errorf("releasing the pad must confirm the item, got %d", confirmed)
+	}
+
+	count := 0
+	m.OnSelect = func(i int) { confirmed = i; count++ }
+	m.Update(firefly.Pad{X: 900}, true, firefly.Buttons{S: true})
+	m.Update(firefly.Pad{X: 900}, true, firefly.Buttons{})
+	m.Update(firefly.Pad{}, false, firefly.Buttons{})
+	if confirmed != 1 || count != 1 {
+		t.Errorf("releasing the pad must not confirm again after S, got %d confirms", count)
+	}
+
+	confirmed = -1
+	m.ConfirmOnRelease = false
+	m.Update(firefly.Pad{X: -900}, true, firefly.Buttons{})
+	m.Update(firefly.Pad{}, false, firefly.Buttons{})
+	if confirmed != -1 {
+		t.Errorf("releasing the pad must not confirm the item")
+	}
+	m.Update(firefly.Pad{X: -900}, true, firefly.Buttons{S: true})
+	if confirmed != 3 {
+		t.Errorf("pressing S must confirm the item, got %d", confirmed)
+	}
+	m.Update(firefly.Pad{X: -900}, true, firefly.Buttons{E: true})
+	if !cancelled || m.Selected() != -1 {
+		t.Errorf("pressing E must cancel the menu")
+	}
+}
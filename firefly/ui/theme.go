@@ -0,0 +1,82 @@
+package ui
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// Colors and the font used by widgets.
+type Theme struct {
+	// The font for all text.
+	Font firefly.Font
+
+	// The main color of text and boxes.
+	Primary firefly.Color
+
+	// The color of disabled options, muted text, etc.
+	Secondary firefly.Color
+
+	// The color of important elements, focused widgets, etc.
+	Accent firefly.Color
+
+	// The background color.
+	BG firefly.Color
+}
+
+// The theme used if the system theme is not available.
+func DefaultTheme(font firefly.Font) Theme {
+	return Theme{
+		Font:      font,
+		Primary:   firefly.ColorBlack,
+		Secondary: firefly.ColorGray,
+		Accent:    firefly.ColorBlue,
+		BG:        firefly.ColorWhite,
+	}
+}
+
+// The theme from the player's system settings.
+//
+// Makes the app look consistent with the rest of the system.
+func SystemTheme(font firefly.Font) Theme {
+	settings := firefly.GetSettings(firefly.GetMe())
+	return ThemeFrom(settings.Theme, font)
+}
+
+// Convert the system theme into a widget theme.
+//
+// Colors that are not set are taken from [DefaultTheme].
+func ThemeFrom(t firefly.Theme, font firefly.Font) Theme {
+	theme := DefaultTheme(font)
+	if t.Primary != firefly.ColorNone {
+		theme.Primary = t.Primary
+	}
+	if t.Secondary != firefly.ColorNone {
+		theme.Secondary = t.Secondary
+	}
+	if t.Accent != firefly.ColorNone {
+		theme.Accent = t.Accent
+	}
+	if t.BG != firefly.ColorNone {
+		theme.BG = t.BG
+	}
+	return theme
+}
+
+// The color for the widget border and text depending on the focus.
+func (t Theme) fg(focused bool) firefly.Color {
+	if focused {
+		return t.Accent
+	}
+	return t.Primary
+}
+
+// Draw the text vertically centered in the rectangle.
+//
+// If center is true, the text is also horizontally centered.
+func (t Theme) drawText(s string, r Rect, c firefly.Color, center bool) {
+	charH := t.Font.CharHeight()
+	x := r.X
+	if center {
+		x += (r.W - t.Font.LineWidth(s)) / 2
+	}
+	// The text point is the baseline, and glyphs are drawn above it.
+	y := r.Y + (r.H+charH)/2
+	firefly.DrawText(s, t.Font, firefly.P(x, y), c)
+}
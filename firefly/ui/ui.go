@@ -0,0 +1,43 @@
+// Ready-to-use user interface components.
+//
+// All components follow the same pattern: call Update on every update
+// with the current input and call Draw on every render.
+// Update doesn't call the host, so components can be easily tested.
+package ui
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// An area on the screen.
+type Rect struct {
+	firefly.Point
+	firefly.Size
+}
+
+// Shortcut for creating a [Rect].
+func R(x, y, w, h int) Rect {
+	return Rect{Point: firefly.P(x, y), Size: firefly.S(w, h)}
+}
+
+// Check if the point is inside of the rectangle.
+func (r Rect) Contains(p firefly.Point) bool {
+	return p.X >= r.X && p.Y >= r.Y && p.X < r.X+r.W && p.Y < r.Y+r.H
+}
+
+// The center point of the rectangle.
+func (r Rect) Center() firefly.Point {
+	return firefly.P(r.X+r.W/2, r.Y+r.H/2)
+}
+
+// Make the rectangle smaller by the given margin on every side.
+func (r Rect) Shrink(margin int) Rect {
+	r.X += margin
+	r.Y += margin
+	r.W -= margin * 2
+	r.H -= margin * 2
+	return r
+}
+
+// Draw the rectangle with the given style.
+func (r Rect) Draw(s firefly.Style) {
+	firefly.DrawRect(r.Point, r.Size, s)
+}
@@ -0,0 +1,442 @@
+package ui
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+var (
+	_ Widget = &Label{}
+	_ Widget = &Button{}
+	_ Widget = &Toggle{}
+	_ Widget = &Slider{}
+	_ Widget = &List{}
+	_ Widget = &Tabs{}
+	_ Widget = &Modal{}
+)
+
+// A static text.
+type Label struct {
+	Rect Rect
+	Text string
+}
+
+// Bounds implements [Widget].
+func (w *Label) Bounds() Rect { return w.Rect }
+
+// Focusable implements [Widget].
+func (w *Label) Focusable() bool { return false }
+
+// Handle implements [Widget].
+func (w *Label) Handle(Event) bool { return false }
+
+// Draw implements [Widget].
+func (w *Label) Draw(t Theme, _ bool) {
+	t.drawText(w.Text, w.Rect, t.Primary, false)
+}
+
+// A button that calls a function when activated.
+type Button struct {
+	Rect    Rect
+	Text    string
+	OnClick func()
+}
+
+// Bounds implements [Widget].
+func (w *Button) Bounds() Rect { return w.Rect }
+
+// Focusable implements [Widget].
+func (w *Button) Focusable() bool { return true }
+
+// Handle implements [Widget].
+//
+// Touching the button activates it.
+func (w *Button) Handle(e Event) bool {
+	if !activated(e, w.Rect) {
+		return e.Kind == Touch
+	}
+	if w.OnClick != nil {
+		w.OnClick()
+	}
+	return true
+}
+
+// Draw implements [Widget].
+func (w *Button) Draw(t Theme, focused bool) {
+	fg := t.fg(focused)
+	w.Rect.Draw(firefly.Style{FillColor: t.BG, StrokeColor: fg, StrokeWidth: 1})
+	t.drawText(w.Text, w.Rect, fg, true)
+}
+
+// Check if the event activates a button-like widget with the given bounds.
+//
+// Holding the touch doesn't activate the widget again.
+func activated(e Event, bounds Rect) bool {
+	if e.Kind == Touch {
+		return e.Start && bounds.Contains(e.Point)
+	}
+	return e.Kind == Activate
+}
+
+// A checkbox with a label.
+type Toggle struct {
+	Rect     Rect
+	Text     string
+	On       bool
+	OnChange func(on bool)
+}
+
+// Bounds implements [Widget].
+func (w *Toggle) Bounds() Rect { return w.Rect }
+
+// Focusable implements [Widget].
+func (w *Toggle) Focusable() bool { return true }
+
+// Handle implements [Widget].
+//
+// Touching the toggle switches it.
+func (w *Toggle) Handle(e Event) bool {
+	if !activated(e, w.Rect) {
+		return e.Kind == Touch
+	}
+	w.On = !w.On
+	if w.OnChange != nil {
+		w.OnChange(w.On)
+	}
+	return true
+}
+
+// Draw implements [Widget].
+func (w *Toggle) Draw(t Theme, focused bool) {
+	fg := t.fg(focused)
+	side := min(w.Rect.H, t.Font.CharHeight())
+	box := Rect{Point: firefly.P(w.Rect.X, w.Rect.Y+(w.Rect.H-side)/2), Size: firefly.S(side, side)}
+	box.Draw(firefly.Style{FillColor: t.BG, StrokeColor: fg, StrokeWidth: 1})
+	if w.On {
+		box.Shrink(2).Draw(firefly.Solid(fg))
+	}
+	label := w.Rect
+	label.X += side + 4
+	label.W -= side + 4
+	t.drawText(w.Text, label, fg, false)
+}
+
+// A horizontal slider for picking a number from a range.
+type Slider struct {
+	Rect     Rect
+	Min      int
+	Max      int
+	Step     int
+	Value    int
+	OnChange func(value int)
+}
+
+// Bounds implements [Widget].
+func (w *Slider) Bounds() Rect { return w.Rect }
+
+// Focusable implements [Widget].
+func (w *Slider) Focusable() bool { return true }
+
+// Handle implements [Widget].
+func (w *Slider) Handle(e Event) bool {
+	step := max(w.Step, 1)
+	value := w.Value
+	switch e.Kind {
+	case Left:
+		value -= step
+	case Right:
+		value += step
+	case Touch:
+		if !w.Rect.Contains(e.Point) {
+			return false
+		}
+		if w.Rect.W <= 1 {
+			return true
+		}
+		value = w.Min + (e.Point.X-w.Rect.X)*(w.Max-w.Min)/(w.Rect.W-1)
+		value = w.Min + (value-w.Min+step/2)/step*step
+	case Activate, Back, Up, Down:
+		return false
+	}
+	w.set(value)
+	return true
+}
+
+func (w *Slider) set(value int) {
+	value = min(max(value, w.Min), w.Max)
+	if value == w.Value {
+		return
+	}
+	w.Value = value
+	if w.OnChange != nil {
+		w.OnChange(value)
+	}
+}
+
+// Draw implements [Widget].
+func (w *Slider) Draw(t Theme, focused bool) {
+	fg := t.fg(focused)
+	midY := w.Rect.Y + w.Rect.H/2
+	firefly.DrawLine(
+		firefly.P(w.Rect.X, midY),
+		firefly.P(w.Rect.X+w.Rect.W-1, midY),
+		firefly.L(t.Secondary, 1),
+	)
+	x := w.Rect.X
+	if w.Max > w.Min {
+		x += (w.Value - w.Min) * (w.Rect.W - 1) / (w.Max - w.Min)
+	}
+	knob := Rect{Point: firefly.P(x-2, w.Rect.Y), Size: firefly.S(5, w.Rect.H)}
+	knob.Draw(firefly.Solid(fg))
+}
+
+// A vertical list of options with scrolling.
+type List struct {
+	Rect     Rect
+	Items    []string
+	Selected int
+	OnSelect func(i int)
+
+	scroll int
+	// The item height from the last draw, used to find the touched item.
+	itemH int
+}
+
+// Bounds implements [Widget].
+func (w *List) Bounds() Rect { return w.Rect }
+
+// Focusable implements [Widget].
+func (w *List) Focusable() bool { return len(w.Items) > 0 }
+
+// The height of a single item.
+func (w *List) itemHeight(t Theme) int {
+	return t.Font.CharHeight() + 2
+}
+
+// Handle implements [Widget].
+func (w *List) Handle(e Event) bool {
+	switch e.Kind {
+	case Up:
+		if w.Selected == 0 {
+			return false
+		}
+		w.Selected--
+	case Down:
+		if w.Selected >= len(w.Items)-1 {
+			return false
+		}
+		w.Selected++
+	case Activate:
+		if w.OnSelect != nil {
+			w.OnSelect(w.Selected)
+		}
+	case Touch:
+		if !w.Rect.Contains(e.Point) {
+			return false
+		}
+		if w.itemH > 0 {
+			i := w.scroll + (e.Point.Y-w.Rect.Y-1)/w.itemH
+			w.Selected = min(max(i, 0), len(w.Items)-1)
+		}
+	case Left, Right, Back:
+		return false
+	}
+	return true
+}
+
+// Scroll the list so that the selected item is visible.
+func (w *List) ScrollTo(visible int) {
+	if w.Selected < w.scroll {
+		w.scroll = w.Selected
+	}
+	if w.Selected >= w.scroll+visible {
+		w.scroll = w.Selected - visible + 1
+	}
+	w.scroll = max(min(w.scroll, len(w.Items)-visible), 0)
+}
+
+// The index of the first visible item.
+func (w *List) Scroll() int {
+	return w.scroll
+}
+
+// Draw implements [Widget].
+func (w *List) Draw(t Theme, focused bool) {
+	w.Rect.Draw(firefly.Style{FillColor: t.BG, StrokeColor: t.fg(focused), StrokeWidth: 1})
+	itemH := w.itemHeight(t)
+	w.itemH = itemH
+	visible := max((w.Rect.H-2)/itemH, 1)
+	w.ScrollTo(visible)
+	end := min(w.scroll+visible, len(w.Items))
+	for i := w.scroll; i < end; i++ {
+		row := Rect{
+			Point: firefly.P(w.Rect.X+1, w.Rect.Y+1+(i-w.scroll)*itemH),
+			Size:  firefly.S(w.Rect.W-2, itemH),
+		}
+		color := t.Primary
+		if i == w.Selected {
+			row.Draw(firefly.Solid(t.fg(focused)))
+			color = t.BG
+		}
+		row.X += 2
+		t.drawText(w.Items[i], row, color, false)
+	}
+}
+
+// A row of tabs, one of which is active.
+type Tabs struct {
+	Rect     Rect
+	Tabs     []string
+	Active   int
+	OnChange func(i int)
+}
+
+// Bounds implements [Widget].
+func (w *Tabs) Bounds() Rect { return w.Rect }
+
+// Focusable implements [Widget].
+func (w *Tabs) Focusable() bool { return len(w.Tabs) > 0 }
+
+// Handle implements [Widget].
+func (w *Tabs) Handle(e Event) bool {
+	active := w.Active
+	switch e.Kind {
+	case Left:
+		if active == 0 {
+			return false
+		}
+		active--
+	case Right:
+		if active >= len(w.Tabs)-1 {
+			return false
+		}
+		active++
+	case Touch:
+		if !w.Rect.Contains(e.Point) {
+			return false
+		}
+		tabW := w.Rect.W / len(w.Tabs)
+		if tabW > 0 {
+			active = min(max((e.Point.X-w.Rect.X)/tabW, 0), len(w.Tabs)-1)
+		}
+	case Activate, Back, Up, Down:
+		return false
+	}
+	if active != w.Active {
+		w.Active = active
+		if w.OnChange != nil {
+			w.OnChange(active)
+		}
+	}
+	return true
+}
+
+// Draw implements [Widget].
+func (w *Tabs) Draw(t Theme, focused bool) {
+	tabW := w.Rect.W / max(len(w.Tabs), 1)
+	for i, name := range w.Tabs {
+		tab := Rect{Point: firefly.P(w.Rect.X+i*tabW, w.Rect.Y), Size: firefly.S(tabW, w.Rect.H)}
+		if i == w.Active {
+			tab.Draw(firefly.Solid(t.fg(focused)))
+			t.drawText(name, tab, t.BG, true)
+		} else {
+			tab.Draw(firefly.Style{FillColor: t.BG, StrokeColor: t.Secondary, StrokeWidth: 1})
+			t.drawText(name, tab, t.Secondary, true)
+		}
+	}
+}
+
+// A dialog shown on top of a [Form] with a message and a row of buttons.
+//
+// Open it with [Form.Open].
+type Modal struct {
+	Title   string
+	Text    string
+	Buttons []string
+
+	// Called when the modal is closed.
+	//
+	// The argument is the index of the chosen button or -1 if the modal was canceled.
+	OnClose func(i int)
+
+	Selected int
+
+	open bool
+}
+
+// Check if the modal is shown.
+func (m *Modal) IsOpen() bool {
+	return m.open
+}
+
+// Close the modal with the given result.
+func (m *Modal) Close(i int) {
+	m.open = false
+	if m.OnClose != nil {
+		m.OnClose(i)
+	}
+}
+
+// Bounds implements [Widget].
+//
+// The modal is always centered on the screen.
+func (m *Modal) Bounds() Rect {
+	const w, h = 180, 80
+	return R((firefly.Width-w)/2, (firefly.Height-h)/2, w, h)
+}
+
+// Focusable implements [Widget].
+func (m *Modal) Focusable() bool { return true }
+
+// Handle implements [Widget].
+func (m *Modal) Handle(e Event) bool {
+	switch e.Kind {
+	case Left:
+		m.Selected = max(m.Selected-1, 0)
+	case Right:
+		m.Selected = min(m.Selected+1, max(len(m.Buttons)-1, 0))
+	case Activate:
+		if len(m.Buttons) == 0 {
+			m.Close(-1)
+		} else {
+			m.Close(m.Selected)
+		}
+	case Back:
+		m.Close(-1)
+	case Touch:
+		for i := range m.Buttons {
+			rect := m.buttonRect(i)
+			if activated(e, rect) {
+				m.Selected = i
+				m.Close(i)
+				return true
+			}
+			if rect.Contains(e.Point) {
+				m.Selected = i
+			}
+		}
+	case Up, Down:
+	}
+	return true
+}
+
+func (m *Modal) buttonRect(i int) Rect {
+	box := m.Bounds()
+	const h = 14
+	w := (box.W - 8) / max(len(m.Buttons), 1)
+	return R(box.X+4+i*w, box.Y+box.H-h-4, w-2, h)
+}
+
+// Draw implements [Widget].
+func (m *Modal) Draw(t Theme, _ bool) {
+	box := m.Bounds()
+	box.Draw(firefly.Style{FillColor: t.BG, StrokeColor: t.Primary, StrokeWidth: 2})
+	charH := t.Font.CharHeight()
+	inner := box.Shrink(4)
+	if m.Title != "" {
+		t.drawText(m.Title, Rect{Point: inner.Point, Size: firefly.S(inner.W, charH)}, t.Accent, true)
+		inner.Y += charH + 2
+	}
+	firefly.DrawText(m.Text, t.Font, inner.Point.Add(firefly.P(0, charH)), t.Primary)
+	for i, name := range m.Buttons {
+		btn := Button{Rect: m.buttonRect(i), Text: name}
+		btn.Draw(t, i == m.Selected)
+	}
+}